@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+
+	"github.com/ravvio/noty/utils"
+)
+
+// Dir returns the directory where cached data is stored.
+func Dir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(base, "noty"), nil
+}
+
+// Load reads the cached value stored under name into v, reports false if
+// nothing was cached yet.
+func Load(name string, v any) (bool, error) {
+	dir, err := Dir()
+	if err != nil {
+		return false, err
+	}
+
+	data, err := os.ReadFile(path.Join(dir, name+".json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Save stores v under name, replacing the previous value atomically so that
+// an interrupted run never leaves a truncated file.
+func Save(name string, v any) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(path.Join(dir, name+".json"), func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
@@ -0,0 +1,350 @@
+package activity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/cache"
+	"github.com/ravvio/noty/cmd/task"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+)
+
+// Prefix of the names of the cached snapshots, one per filter
+const snapshotPrefix = "activity-"
+
+// Tasks not edited and hours entries not created for this long are dropped
+// from the snapshot
+const snapshotRetention = 90 * 24 * time.Hour
+
+// Table column names
+var (
+	keyTime    = "time"
+	keyItem    = "item"
+	keyEvent   = "event"
+	keyDetails = "details"
+	keyEditor  = "editor"
+)
+
+// Event kinds
+const (
+	eventCreated    = "created"
+	eventEdited     = "edited"
+	eventStatus     = "status"
+	eventAssignee   = "assignee"
+	eventEstimate   = "estimate"
+	eventHours      = "hours"
+	eventHoursEdits = "hours changed"
+)
+
+type taskSnapshot struct {
+	StoryID    int
+	Name       string
+	Status     string
+	Assignee   string
+	Estimate   float64
+	LastEdited time.Time
+}
+
+type hoursSnapshot struct {
+	Created time.Time
+	Hours   float64
+}
+
+// snapshot is the last known state of tasks and hours entries, used to
+// compute what changed since the previous run.
+type snapshot struct {
+	Tasks map[string]taskSnapshot
+	Hours map[string]hoursSnapshot
+}
+
+type event struct {
+	Time    time.Time
+	Item    string
+	Kind    string
+	Details string
+	Editor  string
+}
+
+func init() {
+	task.AddFilterFlags(ActivityCmd, "all")
+	ActivityCmd.Flags().Duration("since", 24*time.Hour, "report activity more recent than the given duration (e.g. 2h)")
+}
+
+var ActivityCmd = &cobra.Command{
+	Use:   "activity",
+	Short: "report recent changes to tasks and hours entries",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Load config
+		projectsMap := config.ProjectsMap()
+		usersMap := config.UsersMap()
+		timeFormat := config.DatetimeFormat()
+
+		// Since Flag
+		since, err := cmd.Flags().GetDuration("since")
+		if err != nil {
+			return err
+		}
+		sinceTime := time.Now().Add(-since)
+
+		// Create filters
+		taskFilter, err := task.ParseFilterFlags(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		taskFilter.EditedAfter = &sinceTime
+
+		hoursFilter := notion.HoursFilter{
+			Projects:    taskFilter.Projects,
+			Users:       append(slices.Clone(taskFilter.Users), taskFilter.Assignees...),
+			EditedAfter: &sinceTime,
+		}
+
+		// Fetch
+		var tasks []notion.Task
+		var hoursEntries []notion.HoursEntry
+		group, groupCtx := notionClient.NewGroup(ctx)
+		group.Go(func() error {
+			// The sprint, if any, is looked up while hours are fetched
+			var err error
+			if taskFilter.Sprint, err = task.SprintFilter(groupCtx, cmd, notionClient); err != nil {
+				return err
+			}
+			taskFetcher := notionClient.NewTaskFetcher(groupCtx, config.TasksDatabaseID(), taskFilter)
+			tasks, err = taskFetcher.All()
+			return err
+		})
+		notion.FetchAll(
+			group,
+			notionClient.NewHoursFetcher(groupCtx, config.HoursDatabaseID(), hoursFilter),
+			&hoursEntries,
+		)
+		if err := group.Wait(); err != nil {
+			return err
+		}
+
+		// Load previous snapshot of the same filter
+		snapshotName, err := snapshotNameOf(taskFilter)
+		if err != nil {
+			return err
+		}
+		previous := snapshot{}
+		found, err := cache.Load(snapshotName, &previous)
+		if err != nil {
+			ui.PrintlnfWarn("Could not load previous snapshot: %s", err)
+		}
+		if previous.Tasks == nil {
+			previous.Tasks = make(map[string]taskSnapshot)
+		}
+		if previous.Hours == nil {
+			previous.Hours = make(map[string]hoursSnapshot)
+		}
+
+		// Compute events
+		events := make([]event, 0)
+		for _, t := range tasks {
+			item := fmt.Sprintf("STORY-%d", t.StoryID)
+			editor := usersMap[t.EditorID]
+
+			old, ok := previous.Tasks[t.ID]
+			if !ok {
+				if !t.Created.Before(sinceTime) {
+					events = append(events, event{
+						Time:    t.Created,
+						Item:    item,
+						Kind:    eventCreated,
+						Details: t.Name,
+						Editor:  editor,
+					})
+				} else if found {
+					events = append(events, event{
+						Time:    t.LastEdited,
+						Item:    item,
+						Kind:    eventEdited,
+						Details: t.Name,
+						Editor:  editor,
+					})
+				}
+			} else {
+				if old.Status != t.Status {
+					events = append(events, event{
+						Time:    t.LastEdited,
+						Item:    item,
+						Kind:    eventStatus,
+						Details: fmt.Sprintf("%s → %s", old.Status, t.Status),
+						Editor:  editor,
+					})
+				}
+				if old.Assignee != t.Assignee {
+					events = append(events, event{
+						Time:    t.LastEdited,
+						Item:    item,
+						Kind:    eventAssignee,
+						Details: fmt.Sprintf("%s → %s", old.Assignee, t.Assignee),
+						Editor:  editor,
+					})
+				}
+				if old.Estimate != t.Estimate {
+					events = append(events, event{
+						Time:    t.LastEdited,
+						Item:    item,
+						Kind:    eventEstimate,
+						Details: fmt.Sprintf("%.1f h → %.1f h", old.Estimate, t.Estimate),
+						Editor:  editor,
+					})
+				}
+			}
+
+			previous.Tasks[t.ID] = taskSnapshot{
+				StoryID:    t.StoryID,
+				Name:       t.Name,
+				Status:     t.Status,
+				Assignee:   t.Assignee,
+				Estimate:   t.Estimate,
+				LastEdited: t.LastEdited,
+			}
+		}
+
+		for _, entry := range hoursEntries {
+			project := ""
+			if entry.ProjectID != nil {
+				project = projectsMap[*entry.ProjectID]
+			}
+
+			details := fmt.Sprintf("%.1f h", entry.Hours)
+			if !entry.Date.IsZero() {
+				details += " on " + entry.Date.Format(config.DateFormat())
+			}
+
+			old, ok := previous.Hours[entry.ID]
+			if !ok && !entry.Created.Before(sinceTime) {
+				events = append(events, event{
+					Time:    entry.Created,
+					Item:    project,
+					Kind:    eventHours,
+					Details: details,
+					Editor:  entry.User,
+				})
+			} else if ok && old.Hours != entry.Hours {
+				events = append(events, event{
+					Time:    entry.LastEdited,
+					Item:    project,
+					Kind:    eventHoursEdits,
+					Details: fmt.Sprintf("%.1f h → %.1f h", old.Hours, entry.Hours),
+					Editor:  entry.User,
+				})
+			}
+
+			previous.Hours[entry.ID] = hoursSnapshot{
+				Created: entry.Created,
+				Hours:   entry.Hours,
+			}
+		}
+
+		// Store snapshot, tasks out of the filter are no longer returned
+		// and are dropped once they are older than the retention
+		for id, t := range previous.Tasks {
+			if time.Since(t.LastEdited) > snapshotRetention {
+				delete(previous.Tasks, id)
+			}
+		}
+		for id, entry := range previous.Hours {
+			if time.Since(entry.Created) > snapshotRetention {
+				delete(previous.Hours, id)
+			}
+		}
+		if err := cache.Save(snapshotName, previous); err != nil {
+			ui.PrintlnfWarn("Could not save snapshot: %s", err)
+		}
+
+		slices.SortStableFunc(events, func(a, b event) int {
+			return a.Time.Compare(b.Time)
+		})
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		columns := []etable.TableColumn{
+			etable.NewTableColumn(keyTime, "Time"),
+			etable.NewTableColumn(keyItem, "Item"),
+			etable.NewTableColumn(keyEvent, "Event"),
+			etable.NewTableColumn(keyDetails, "Details").WithMaxWidth(60),
+			etable.NewTableColumn(keyEditor, "By").WithEmptyString("-"),
+		}
+
+		// Add rows
+		rows := make([]etable.TableRow, 0, len(events))
+		for _, e := range events {
+			rows = append(rows, etable.TableRow{
+				keyTime:    e.Time.Local().Format(timeFormat),
+				keyItem:    e.Item,
+				keyEvent:   e.Kind,
+				keyDetails: e.Details,
+				keyEditor:  e.Editor,
+			})
+		}
+
+		// Render result
+		fmt.Println()
+		fmt.Println(etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows).Render())
+
+		ui.PrintlnfInfo(
+			"\nFound %d events in %d tasks and %d hours entries edited since %s",
+			len(rows),
+			len(tasks),
+			len(hoursEntries),
+			sinceTime.Format(timeFormat),
+		)
+		if !found {
+			ui.PrintlnWarn("No previous snapshot found, changes to existing tasks will be reported from the next run")
+		}
+
+		return nil
+	},
+}
+
+// snapshotNameOf returns the name of the snapshot of the tasks matching
+// filter, the same for filters differing only in the order of their values.
+func snapshotNameOf(filter notion.TaskFilter) (string, error) {
+	filter.EditedAfter = nil
+	for _, values := range []*[]string{
+		&filter.Names,
+		&filter.Projects,
+		&filter.Users,
+		&filter.Assignees,
+		&filter.Reviewers,
+		&filter.Statuses,
+	} {
+		*values = slices.Sorted(slices.Values(*values))
+	}
+	data, err := json.Marshal(struct {
+		Filter     notion.TaskFilter
+		SprintType string
+	}{filter, fmt.Sprintf("%T", filter.Sprint)})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return snapshotPrefix + hex.EncodeToString(sum[:8]), nil
+}
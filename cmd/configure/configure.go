@@ -1,8 +1,8 @@
 package configure
 
 import (
-	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/ravvio/easycli-ui/espinner"
@@ -13,15 +13,73 @@ import (
 	"github.com/spf13/viper"
 )
 
+// setting is a value asked by the configuration wizard, that can also be
+// given with a flag or an environment variable.
+type setting struct {
+	key   string
+	flag  string
+	title string
+
+	// Optional settings may be left empty
+	optional bool
+}
+
+var databaseSettings = []setting{
+	{key: config.KeyTasksDatabaseID, flag: "tasks-db", title: "Tasks Database ID"},
+	{key: config.KeyProjectsDatabaseID, flag: "projects-db", title: "Projects Database ID"},
+	{key: config.KeySprintsDatabaseID, flag: "sprints-db", title: "Sprints Database ID"},
+	{key: config.KeyHoursDatabaseID, flag: "hours-db", title: "Hours Entries Database ID"},
+	{
+		key:      config.KeyCommissionsDatabaseID,
+		flag:     "commissions-db",
+		title:    "Commissions Database ID (optional)",
+		optional: true,
+	},
+}
+
+var (
+	emotesSetting     = setting{key: config.KeyUseEmotes, flag: "use-emotes"}
+	dateFormatSetting = setting{key: config.KeyDateFormat, flag: "date-format"}
+	timeFormatSetting = setting{key: config.KeyDatetimeFormat, flag: "time-format"}
+	meSetting         = setting{key: config.KeyMe, flag: "me"}
+)
+
 func init() {
+	ConfigCmd.AddCommand(RefreshCmd)
+
 	ConfigCmd.Flags().BoolP("redo", "r", false, "repeat all configuration steps")
+	ConfigCmd.Flags().Bool("non-interactive", false, "do not prompt, fail if a required value is missing")
+
+	for _, s := range databaseSettings {
+		ConfigCmd.Flags().String(s.flag, "", fmt.Sprintf("%s (env %s)", s.title, config.EnvName(s.key)))
+	}
+	ConfigCmd.Flags().Bool(
+		emotesSetting.flag,
+		true,
+		fmt.Sprintf("use emotes in outputs (env %s)", config.EnvName(emotesSetting.key)),
+	)
+	ConfigCmd.Flags().String(
+		dateFormatSetting.flag,
+		"",
+		fmt.Sprintf("date format as a Go layout, e.g. 2006-01-02 (env %s)", config.EnvName(dateFormatSetting.key)),
+	)
+	ConfigCmd.Flags().String(
+		timeFormatSetting.flag,
+		"",
+		fmt.Sprintf("time format as a Go layout, e.g. 15:04 (env %s as full datetime layout)", config.EnvName(timeFormatSetting.key)),
+	)
+	ConfigCmd.Flags().String(
+		meSetting.flag,
+		"",
+		fmt.Sprintf("name of the user running noty (env %s)", config.EnvName(meSetting.key)),
+	)
 }
 
 var ConfigCmd = &cobra.Command{
 	Use:   "configure",
 	Short: "",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		client := notion.NewClient()
 
 		_, err := config.Load()
@@ -34,53 +92,57 @@ var ConfigCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-
-		// Set task db
-		tasksDatabaseID := config.TasksDatabaseID()
-		if exit, err := ui.NewTextInput(
-			"Tasks Database ID",
-			&tasksDatabaseID,
-			tasksDatabaseID,
-		).Run(); err != nil || exit {
+		nonInteractive, err := cmd.Flags().GetBool("non-interactive")
+		if err != nil {
 			return err
 		}
-		viper.Set(config.KeyTasksDatabaseID, tasksDatabaseID)
+		interactive := !nonInteractive
 
-		// Set projects db
-		projectsDatabaseID := config.ProjectsDatabaseID()
-		if exit, err := ui.NewTextInput(
-			"Projects Database ID",
-			&projectsDatabaseID,
-			projectsDatabaseID,
-		).Run(); err != nil || exit {
-			return err
-		}
-		viper.Set(config.KeyProjectsDatabaseID, projectsDatabaseID)
+		// Set databases
+		for _, s := range databaseSettings {
+			provided, err := s.apply(cmd)
+			if err != nil {
+				return err
+			}
+			if provided || !interactive {
+				continue
+			}
 
-		// Set sprints db
-		sprintsDatabaseID := config.SprintsDatabaseID()
-		if exit, err := ui.NewTextInput(
-			"Sprints Database ID",
-			&sprintsDatabaseID,
-			sprintsDatabaseID,
-		).Run(); err != nil || exit {
-			return err
+			value := viper.GetString(s.key)
+			if exit, err := ui.NewTextInput(
+				s.title,
+				&value,
+				value,
+			).Run(); err != nil || exit {
+				return err
+			}
+			viper.Set(s.key, value)
 		}
-		viper.Set(config.KeySprintsDatabaseID, sprintsDatabaseID)
 
-		// Set hours db
-		hoursDatabaseID := config.HoursDatabaseID()
-		if exit, err := ui.NewTextInput(
-			"Hours Entries Database ID",
-			&hoursDatabaseID,
-			hoursDatabaseID,
-		).Run(); err != nil || exit {
-			return err
+		if !interactive {
+			missing := make([]string, 0)
+			if os.Getenv("NOTION_API_KEY") == "" {
+				missing = append(missing, "notion API key (env NOTION_API_KEY)")
+			}
+			for _, s := range databaseSettings {
+				if viper.GetString(s.key) == "" && !s.optional {
+					missing = append(missing, fmt.Sprintf(
+						"%s (--%s or env %s)",
+						s.key,
+						s.flag,
+						config.EnvName(s.key),
+					))
+				}
+			}
+			if len(missing) > 0 {
+				return fmt.Errorf("missing configuration values:\n  %s", strings.Join(missing, "\n  "))
+			}
 		}
-		viper.Set(config.KeyHoursDatabaseID, hoursDatabaseID)
 
 		// Emotes
-		if redo || !viper.IsSet(config.KeyUseEmotes) {
+		if provided, err := emotesSetting.apply(cmd); err != nil {
+			return err
+		} else if !provided && interactive && (redo || !viper.IsSet(config.KeyUseEmotes)) {
 			useEmotes := config.UseEmotes()
 			if exit, err := ui.NewSelectInput(
 				"Do you want to use emotes (✅, 🔴, 🚀) in outputs?",
@@ -96,7 +158,11 @@ var ConfigCmd = &cobra.Command{
 		}
 
 		// Date format
-		if redo || !viper.IsSet(config.KeyDateFormat) {
+		dateFormatProvided, err := dateFormatSetting.apply(cmd)
+		if err != nil {
+			return err
+		}
+		if !dateFormatProvided && interactive && (redo || !viper.IsSet(config.KeyDateFormat)) {
 			dateFormat := config.DateFormat()
 			if exit, err := ui.NewSelectInput(
 				"Select your preferred date format",
@@ -112,16 +178,23 @@ var ConfigCmd = &cobra.Command{
 		}
 
 		// Time format
-		if redo || !viper.IsSet(config.KeyDatetimeFormat) {
-			dateFormat := config.DateFormat()
-			datetimeFormatSplit := strings.Split(config.DatetimeFormat(), " ")
-			var timeFormat string
-			if len(datetimeFormatSplit) < 2 {
-				timeFormat = ""
-			} else {
-				timeFormat = datetimeFormatSplit[1]
-			}
+		dateFormat := config.DateFormat()
+		datetimeFormatSplit := strings.SplitN(config.DatetimeFormat(), " ", 2)
+		var timeFormat string
+		if len(datetimeFormatSplit) < 2 {
+			timeFormat = ""
+		} else {
+			timeFormat = datetimeFormatSplit[1]
+		}
 
+		if cmd.Flags().Changed(timeFormatSetting.flag) {
+			if timeFormat, err = cmd.Flags().GetString(timeFormatSetting.flag); err != nil {
+				return err
+			}
+			viper.Set(config.KeyDatetimeFormat, fmt.Sprintf("%s %s", dateFormat, timeFormat))
+		} else if timeFormatSetting.fromEnv() {
+			// The environment gives the full datetime layout
+		} else if interactive && (redo || !viper.IsSet(config.KeyDatetimeFormat)) {
 			if exit, err := ui.NewSelectInput(
 				"Select your preferred time format",
 				[]ui.SelectItem[string]{
@@ -133,48 +206,145 @@ var ConfigCmd = &cobra.Command{
 				return err
 			}
 			viper.Set(config.KeyDatetimeFormat, fmt.Sprintf("%s %s", dateFormat, timeFormat))
+		} else if dateFormatProvided {
+			viper.Set(config.KeyDatetimeFormat, fmt.Sprintf("%s %s", dateFormat, timeFormat))
 		}
 
 		// Fetch all users
-		s := espinner.NewSpinner(
-			"Loading users",
-			func() error {
-				fetcherUsers := client.NewUserFetcher(ctx, true)
-				users, err := fetcherUsers.All()
-				if err != nil {
-					return err
-				}
-				viper.Set(config.KeyUsers, users)
-				return nil
-			},
-		)
-		if err = s.Spin(); err != nil {
+		var users []notion.NotionUser
+		if err := spin(interactive, "Loading users", func() error {
+			users, err = config.FetchUsers(ctx, client)
+			if err != nil {
+				return err
+			}
+			config.SetUsers(users)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// Me
+		if provided, err := meSetting.apply(cmd); err != nil {
 			return err
+		} else if provided {
+			user, err := config.ParseUser(ctx, config.Me())
+			if err != nil {
+				return err
+			}
+			viper.Set(config.KeyMe, user.Name)
+		} else if interactive && (redo || !viper.IsSet(config.KeyMe)) && len(users) > 0 {
+			me := config.Me()
+			items := make([]ui.SelectItem[string], 0, len(users))
+			for _, user := range users {
+				items = append(items, ui.NewSelectItem(user.Name, user.Name))
+			}
+			if exit, err := ui.NewSelectInput(
+				"Who are you? (used for 'me' in user filters)",
+				items,
+				&me,
+			).Run(); err != nil || exit {
+				return err
+			}
+			viper.Set(config.KeyMe, me)
 		}
 
 		// Fetch all projects
-		s = espinner.NewSpinner(
-			"Loading projects",
-			func() error {
-				fetcherProjects := client.NewProjectFetcher(ctx, config.ProjectsDatabaseID())
-				projects, err := fetcherProjects.All()
+		if err := spin(interactive, "Loading projects", func() error {
+			projects, err := config.FetchProjects(ctx, client)
+			if err != nil {
+				return err
+			}
+			config.SetProjects(projects)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// Fetch all commissions
+		if config.CommissionsDatabaseID() != "" {
+			if err := spin(interactive, "Loading commissions", func() error {
+				commissions, err := config.FetchCommissions(ctx, client)
 				if err != nil {
 					return err
 				}
-				viper.Set(config.KeyProjects, projects)
+				config.SetCommissions(commissions)
 				return nil
-			},
-		)
-		if err = s.Spin(); err != nil {
-			return err
+			}); err != nil {
+				return err
+			}
 		}
 
 		filename, err := config.Save()
 		if err != nil {
 			return err
 		}
+		config.MarkRefreshed()
 		ui.PrintlnfInfo("Configuration saved to %s", filename)
 
 		return nil
 	},
 }
+
+var RefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "refresh the cached users and projects",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		client := notion.NewClient()
+
+		s := espinner.NewSpinner(
+			"Refreshing users and projects",
+			func() error {
+				return config.Refresh(ctx, client)
+			},
+		)
+		if err := s.Spin(); err != nil {
+			return err
+		}
+		ui.PrintlnfInfo(
+			"Refreshed %d users and %d projects",
+			len(config.Users()),
+			len(config.Projects()),
+		)
+
+		return nil
+	},
+}
+
+// apply sets the value of the setting from its flag, if given. Reports
+// whether the value was given by the flag or by the environment.
+func (s setting) apply(cmd *cobra.Command) (bool, error) {
+	if !cmd.Flags().Changed(s.flag) {
+		return s.fromEnv(), nil
+	}
+
+	flag := cmd.Flags().Lookup(s.flag)
+	switch flag.Value.Type() {
+	case "bool":
+		value, err := cmd.Flags().GetBool(s.flag)
+		if err != nil {
+			return false, err
+		}
+		viper.Set(s.key, value)
+	default:
+		viper.Set(s.key, flag.Value.String())
+	}
+	return true, nil
+}
+
+// fromEnv reports whether the value of the setting is given by the
+// environment.
+func (s setting) fromEnv() bool {
+	_, ok := os.LookupEnv(config.EnvName(s.key))
+	return ok
+}
+
+// spin runs f showing a spinner with the given title, or silently when not
+// interactive.
+func spin(interactive bool, title string, f func() error) error {
+	if !interactive {
+		return f()
+	}
+	s := espinner.NewSpinner(title, f)
+	return s.Spin()
+}
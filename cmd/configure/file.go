@@ -0,0 +1,66 @@
+package configure
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/ui"
+	"github.com/ravvio/noty/utils"
+)
+
+func init() {
+	ConfigFileCmd.AddCommand(ImportCmd)
+	ConfigFileCmd.AddCommand(ExportCmd)
+	ConfigFileCmd.AddCommand(GetCmd)
+	ConfigFileCmd.AddCommand(SetCmd)
+	ConfigFileCmd.AddCommand(ListCmd)
+	ConfigFileCmd.AddCommand(EditCmd)
+}
+
+var ConfigFileCmd = &cobra.Command{
+	Use:   "config",
+	Short: "read, write and share the configuration",
+}
+
+var ImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "merge a YAML configuration file into the current configuration",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Configuration may not exist yet
+		if _, err := config.Load(); err != nil {
+			return err
+		}
+
+		filename, err := config.Import(args[0])
+		if err != nil {
+			return err
+		}
+		ui.PrintlnfInfo("Configuration imported to %s", filename)
+		return nil
+	},
+}
+
+var ExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "export the configuration as YAML, to stdout if no file is given",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return config.Export(os.Stdout)
+		}
+
+		abs, err := filepath.Abs(args[0])
+		if err != nil {
+			return err
+		}
+		if err := utils.WriteFileAtomic(abs, config.Export); err != nil {
+			return err
+		}
+		ui.PrintlnfInfo("Configuration exported to %s", abs)
+		return nil
+	},
+}
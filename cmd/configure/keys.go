@@ -0,0 +1,197 @@
+package configure
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/ui"
+	"github.com/ravvio/noty/utils"
+)
+
+// Table column names
+var (
+	keyKey    = "key"
+	keyValue  = "value"
+	keySource = "source"
+)
+
+var GetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "print the effective value of a configuration key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := strings.ToLower(args[0])
+		if _, err := config.LookupKey(key); err != nil {
+			return err
+		}
+
+		fmt.Println(formatValue(key, viper.Get(key)))
+		ui.PrintlnfInfo("from %s", config.Source(key))
+		return nil
+	},
+}
+
+var SetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "validate a value and write it to the configuration file",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filename, err := config.SetInFile(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		ui.PrintlnfInfo("Set '%s' in %s", strings.ToLower(args[0]), filename)
+
+		if source := config.Source(args[0]); source != config.SourceFile {
+			ui.PrintlnfWarn("The effective value of '%s' still comes from %s", strings.ToLower(args[0]), source)
+		}
+		return nil
+	},
+}
+
+var ListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the effective configuration values and where they come from",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		columns := []etable.TableColumn{
+			etable.NewTableColumn(keyKey, "Key"),
+			etable.NewTableColumn(keyValue, "Value").WithMaxWidth(60),
+			etable.NewTableColumn(keySource, "Source"),
+		}
+
+		// Add rows
+		rows := make([]etable.TableRow, 0, len(config.Keys))
+		for _, info := range config.Keys {
+			if !info.Map {
+				rows = append(rows, etable.TableRow{
+					keyKey:    info.Key,
+					keyValue:  formatValue(info.Key, viper.Get(info.Key)),
+					keySource: config.Source(info.Key),
+				})
+				continue
+			}
+
+			values := viper.GetStringMap(info.Key)
+			subKeys := utils.MapKeys(values)
+			slices.Sort(subKeys)
+			for _, sub := range subKeys {
+				key := info.Key + "." + sub
+				rows = append(rows, etable.TableRow{
+					keyKey:    key,
+					keyValue:  formatValue(key, values[sub]),
+					keySource: config.Source(key),
+				})
+			}
+		}
+
+		// Render result
+		fmt.Println()
+		fmt.Println(etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows).Render())
+		if filename := viper.ConfigFileUsed(); filename != "" {
+			ui.PrintlnfInfo("\nConfiguration file %s", filename)
+		}
+		return nil
+	},
+}
+
+var EditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "open the configuration file in $EDITOR and validate it",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// An invalid file can still be edited
+		if _, err := config.Load(); err != nil {
+			ui.PrintlnfWarn("%s", err)
+		}
+		filename := viper.ConfigFileUsed()
+		if filename == "" {
+			return fmt.Errorf("configuration not found, run the 'configure' command to generate it")
+		}
+
+		editor := os.Getenv("VISUAL")
+		if editor == "" {
+			editor = os.Getenv("EDITOR")
+		}
+		if editor == "" {
+			editor = "vi"
+		}
+		editorArgs := strings.Fields(editor)
+
+		edit := exec.Command(editorArgs[0], append(editorArgs[1:], filename)...)
+		edit.Stdin = os.Stdin
+		edit.Stdout = os.Stdout
+		edit.Stderr = os.Stderr
+		if err := edit.Run(); err != nil {
+			return fmt.Errorf("editor '%s' failed: %s", editor, err)
+		}
+
+		// Validate the new configuration
+		if _, err := config.Load(); err != nil {
+			return err
+		}
+		errs := config.Validate()
+		for _, err := range errs {
+			ui.PrintlnfWarn("%s", err)
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("configuration has %d invalid values, run 'noty config edit' to fix them", len(errs))
+		}
+		ui.PrintlnfInfo("Configuration saved to %s", filename)
+		return nil
+	},
+}
+
+// formatValue formats a configuration value on a single line.
+func formatValue(key string, value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []any:
+		if key == config.KeyUsers || key == config.KeyProjects {
+			return fmt.Sprintf("%d entries", len(v))
+		}
+		if key == config.KeyStatuses {
+			values := make([]string, 0, len(v))
+			for _, status := range config.Statuses() {
+				values = append(values, fmt.Sprintf("%s=%s", status.Code, status.Name))
+			}
+			return strings.Join(values, ", ")
+		}
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return strings.Join(values, ",")
+	case []string:
+		return strings.Join(v, ",")
+	case map[string]any:
+		keys := utils.MapKeys(v)
+		slices.Sort(keys)
+		values := make([]string, 0, len(keys))
+		for _, k := range keys {
+			values = append(values, fmt.Sprintf("%s=%s", k, formatValue(key+"."+k, v[k])))
+		}
+		return strings.Join(values, ", ")
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,101 @@
+package configure
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/cmd/task"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+)
+
+// Table column names
+var (
+	keyName  = "name"
+	keyCode  = "code"
+	keyEmote = "emote"
+	keyColor = "color"
+	keyOpen  = "open"
+	keyRole  = "role"
+)
+
+func init() {
+	ConfigCmd.AddCommand(StatusesCmd)
+
+	StatusesCmd.Flags().Bool("dry-run", false, "show the discovered statuses without saving them")
+}
+
+var StatusesCmd = &cobra.Command{
+	Use:   "statuses",
+	Short: "discover the task statuses from the tasks database",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		client := notion.NewClient()
+
+		// Flags
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+
+		// Fetch status options
+		options, err := client.FetchStatusOptions(ctx, config.TasksDatabaseID(), "Status")
+		if err != nil {
+			return err
+		}
+		statuses := config.StatusesFromOptions(options)
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		columns := []etable.TableColumn{
+			etable.NewTableColumn(keyName, "Name").WithStyleFunc(task.StatusStyle),
+			etable.NewTableColumn(keyCode, "Code"),
+			etable.NewTableColumn(keyEmote, "Emote"),
+			etable.NewTableColumn(keyColor, "Color"),
+			etable.NewTableColumn(keyOpen, "Open"),
+			etable.NewTableColumn(keyRole, "Role").WithEmptyString("-"),
+		}
+
+		// Add rows
+		rows := make([]etable.TableRow, 0, len(statuses))
+		for _, status := range statuses {
+			rows = append(rows, etable.TableRow{
+				keyName:  status.Name,
+				keyCode:  status.Code,
+				keyEmote: status.Emote,
+				keyColor: status.Color,
+				keyOpen:  fmt.Sprintf("%t", status.Open),
+				keyRole:  status.Role,
+			})
+		}
+
+		// Render result
+		fmt.Println()
+		fmt.Println(etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows).Render())
+
+		if dryRun {
+			return nil
+		}
+		filename, err := config.SaveStatuses(statuses)
+		if err != nil {
+			return err
+		}
+		ui.PrintlnfInfo("\nSaved %d statuses to %s, edit them with 'noty config edit'", len(statuses), filename)
+
+		return nil
+	},
+}
@@ -0,0 +1,125 @@
+package hours
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/export"
+	"github.com/ravvio/noty/grouping"
+	"github.com/ravvio/noty/notion"
+)
+
+// Dimensions entries can be grouped by
+var groupChoices = []string{"user", "project", "commission"}
+
+// Grouping column names
+var (
+	keyMin = "min"
+	keyMax = "max"
+	keyAvg = "avg"
+)
+
+// entryDimensions returns the dimensions entries can be grouped by, keyed by
+// their name in the group-by flag.
+func entryDimensions(
+	projectsMap map[string]string,
+	commissionName func(id string) string,
+) map[string]grouping.Dimension[notion.HoursEntry] {
+	return map[string]grouping.Dimension[notion.HoursEntry]{
+		"user": {
+			Key:   keyUser,
+			Title: "User",
+			Value: func(entry notion.HoursEntry) string { return entry.User },
+		},
+		"project": {
+			Key:   keyProject,
+			Title: "Project",
+			Value: func(entry notion.HoursEntry) string {
+				if entry.ProjectID != nil {
+					return projectsMap[*entry.ProjectID]
+				}
+				return ""
+			},
+		},
+		"commission": {
+			Key:   keyCommission,
+			Title: "Commission",
+			Value: func(entry notion.HoursEntry) string {
+				if entry.CommissionID != nil {
+					return commissionName(*entry.CommissionID)
+				}
+				return ""
+			},
+		},
+	}
+}
+
+// parseGrouping returns the dimensions named in the group-by flag.
+func parseGrouping(
+	names []string,
+	projectsMap map[string]string,
+	commissionName func(id string) string,
+) ([]grouping.Dimension[notion.HoursEntry], error) {
+	dimensions := entryDimensions(projectsMap, commissionName)
+	dims := make([]grouping.Dimension[notion.HoursEntry], 0, len(names))
+	for i, name := range names {
+		if slices.Contains(names[:i], name) {
+			return nil, fmt.Errorf("cannot group by '%s' more than once", name)
+		}
+		dims = append(dims, dimensions[name])
+	}
+	return dims, nil
+}
+
+// groupingTable returns the columns and rows of entries grouped by dims,
+// with statistics of the hours of entries.
+func groupingTable(
+	entries []notion.HoursEntry,
+	dims []grouping.Dimension[notion.HoursEntry],
+) ([]etable.TableColumn, []etable.TableRow) {
+	aggregate := func(entries []notion.HoursEntry) etable.TableRow {
+		hours := make([]float64, 0, len(entries))
+		for _, entry := range entries {
+			hours = append(hours, entry.Hours)
+		}
+		stats := grouping.NewStats(hours)
+		return etable.TableRow{
+			keyEntries: fmt.Sprintf("%d", stats.Count),
+			keyHours:   fmt.Sprintf("%.1f h", stats.Sum),
+			keyMin:     fmt.Sprintf("%.1f h", stats.Min),
+			keyMax:     fmt.Sprintf("%.1f h", stats.Max),
+			keyAvg:     fmt.Sprintf("%.1f h", stats.Avg),
+		}
+	}
+
+	root := grouping.By(entries, dims)
+	columns := grouping.Columns(
+		dims,
+		etable.NewTableColumn(keyEntries, "Entries").WithAlignment(etable.TableAlignmentRight),
+		etable.NewTableColumn(keyHours, "Hours").WithAlignment(etable.TableAlignmentRight),
+		etable.NewTableColumn(keyMin, "Min").WithAlignment(etable.TableAlignmentRight),
+		etable.NewTableColumn(keyMax, "Max").WithAlignment(etable.TableAlignmentRight),
+		etable.NewTableColumn(keyAvg, "Avg").WithAlignment(etable.TableAlignmentRight),
+	)
+	return columns, grouping.Rows(root, dims, aggregate)
+}
+
+// groupingExport returns the grouping table of dims to export, with the
+// hours typed as hours and the counts as integers.
+func groupingExport(
+	columns []etable.TableColumn,
+	rows []etable.TableRow,
+	dims []grouping.Dimension[notion.HoursEntry],
+) export.Table {
+	kinds := map[string]export.Kind{
+		"Hours": export.KindHours,
+		"Min":   export.KindHours,
+		"Max":   export.KindHours,
+		"Avg":   export.KindHours,
+	}
+	for _, dim := range dims {
+		kinds[dim.Title] = export.KindString
+	}
+	return export.Table{Name: "Grouped", Columns: columns, Rows: rows, Kinds: kinds, Others: export.KindInt}
+}
@@ -1,15 +1,15 @@
 package hours
 
 import (
-	"context"
 	"fmt"
-	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/ravvio/easycli-ui/etable"
 	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/export"
 	"github.com/ravvio/noty/flags"
 	"github.com/ravvio/noty/notion"
 	"github.com/ravvio/noty/ui"
@@ -17,19 +17,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type EntryGroupingValues struct {
-	Entries int
-	Hours   float64
-}
-
 var (
-	keyId      = "id"
-	keyDate    = "date"
-	keyUser    = "user"
-	keyProject = "project"
-	// TODO
-	// keyTask
-	// keyCommission
+	keyId          = "id"
+	keyDate        = "date"
+	keyUser        = "user"
+	keyProject     = "project"
+	keyTask        = "task"
+	keyCommission  = "commission"
 	keyHours       = "hours"
 	keyCreatedTime = "createdTime"
 	keyEntries     = "entries"
@@ -39,6 +33,8 @@ var hoursColumns = map[string]etable.TableColumn{
 	keyDate:        etable.NewTableColumn(keyDate, "Date"),
 	keyUser:        etable.NewTableColumn(keyUser, "User"),
 	keyProject:     etable.NewTableColumn(keyProject, "Project"),
+	keyTask:        etable.NewTableColumn(keyTask, "Task").WithMaxWidth(40),
+	keyCommission:  etable.NewTableColumn(keyCommission, "Commission"),
 	keyHours:       etable.NewTableColumn(keyHours, "Hours").WithAlignment(etable.TableAlignmentRight),
 	keyCreatedTime: etable.NewTableColumn(keyCreatedTime, "Created"),
 }
@@ -50,12 +46,15 @@ func init() {
 	// Project
 	HoursCmd.Flags().StringSliceP("project", "p", []string{}, "filter by project(s)")
 
+	// Commission
+	HoursCmd.Flags().StringSliceP("commission", "c", []string{}, "filter by commission(s)")
+
 	// Date
 	HoursCmd.Flags().VarP(
 		flags.StringChoiceOrDate(
 			[]string{"all", "today", "yesterday"},
 			"all",
-			config.DateFormat(),
+			config.DateFormat,
 		),
 		"date",
 		"d",
@@ -64,13 +63,13 @@ func init() {
 
 	// Grouping
 	HoursCmd.Flags().VarP(
-		flags.StringChoice(
-			[]string{"user", "project"},
-			"",
+		flags.StringChoiceSlice(
+			groupChoices,
+			[]string{},
 		),
 		"group-by",
 		"g",
-		"define if and how to group data [user]",
+		fmt.Sprintf("group data by one or more comma separated dimensions, e.g. project,user %v", groupChoices),
 	)
 
 	// Limits
@@ -101,18 +100,21 @@ func init() {
 	HoursCmd.MarkFlagsMutuallyExclusive("columns", "add-columns")
 
 	// Export
-	HoursCmd.Flags().StringP("outfile", "o", "", "export result as csv")
+	HoursCmd.Flags().StringP("outfile", "o", "", "export result as xlsx, json, md or csv, by extension")
+
+	// Watch
+	HoursCmd.Flags().Duration("watch", 0, "re-run the query every given interval (e.g. 30s) highlighting changes")
+	HoursCmd.MarkFlagsMutuallyExclusive("watch", "outfile")
 }
 
 var HoursCmd = &cobra.Command{
 	Use:   "hours",
 	Short: "fetch and analyze working hours",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		notionClient := notion.NewClient()
 
 		// Load config
-		projectsList := config.Projects()
 		projectsMap := config.ProjectsMap()
 		timeFormat := config.DatetimeFormat()
 		dateFormat := config.DateFormat()
@@ -124,7 +126,11 @@ var HoursCmd = &cobra.Command{
 		if users, err := cmd.Flags().GetStringSlice("users"); err != nil {
 			return err
 		} else if len(users) != 0 {
-			for _, user := range config.ParseUsers(users) {
+			users, err := config.ParseUsers(ctx, users)
+			if err != nil {
+				return err
+			}
+			for _, user := range users {
 				filter.Users = append(filter.Users, user.ID)
 			}
 		}
@@ -133,28 +139,33 @@ var HoursCmd = &cobra.Command{
 		if projects, err := cmd.Flags().GetStringSlice("project"); err != nil {
 			return err
 		} else if len(projects) > 0 {
-			for _, projectName := range projects {
-				l := len(filter.Projects)
-
-				projectName = strings.ToLower(projectName)
-				for _, project := range projectsList {
-					if strings.Contains(
-						strings.ToLower(project.Name),
-						projectName,
-					) {
-						filter.Projects = append(filter.Projects, project.ID)
-					}
-				}
+			projects, err := config.ParseProjects(ctx, projects)
+			if err != nil {
+				return err
+			}
+			for _, project := range projects {
+				filter.Projects = append(filter.Projects, project.ID)
+			}
+		}
 
-				if l == len(filter.Projects) {
-					return fmt.Errorf("no project found for '%s'", projectName)
-				}
+		// Commissions Flag
+		if commissions, err := cmd.Flags().GetStringSlice("commission"); err != nil {
+			return err
+		} else if len(commissions) > 0 {
+			commissions, err := config.ParseCommissions(ctx, commissions)
+			if err != nil {
+				return err
+			}
+			for _, commission := range commissions {
+				filter.Commissions = append(filter.Commissions, commission.ID)
 			}
 		}
 
 		// Date Flag
 		if date, err := cmd.Flags().GetString("date"); err != nil {
 			return err
+		} else if date == "all" {
+			// No date filter
 		} else if date == "today" {
 			filter.Date = notion.HoursDateToday{}
 		} else if date == "yesterday" {
@@ -169,26 +180,38 @@ var HoursCmd = &cobra.Command{
 			}
 		}
 
-		// Create fetcher
-		hoursFetcher := notionClient.NewHoursFetcher(
-			ctx,
-			config.HoursDatabaseID(),
-			filter,
-		)
-
 		// All / Limit Flag
+		limit := -1
 		if all, err := cmd.Flags().GetBool("all"); err != nil {
 			return fmt.Errorf("failed request: %s", err)
 		} else if !all {
-			if limit, err := cmd.Flags().GetInt("limit"); err != nil {
+			if limit, err = cmd.Flags().GetInt("limit"); err != nil {
 				return err
-			} else {
-				hoursFetcher = hoursFetcher.WithLimit(limit)
 			}
 		}
 
-		// Fetch
-		hoursEntries, err := hoursFetcher.All()
+		// Grouping Flag
+		// Titles of the related pages, resolved at each refresh
+		var titles map[string]string
+		groupBy, err := cmd.Flags().GetStringSlice("group-by")
+		if err != nil {
+			return err
+		}
+		dims, err := parseGrouping(
+			groupBy,
+			projectsMap,
+			func(id string) string { return titles[id] },
+		)
+		if err != nil {
+			return err
+		}
+
+		// Watch Flag
+		watch, err := cmd.Flags().GetDuration("watch")
+		if err != nil {
+			return err
+		}
+		quiet, err := cmd.Flags().GetBool("quiet")
 		if err != nil {
 			return err
 		}
@@ -223,120 +246,193 @@ var HoursCmd = &cobra.Command{
 			columns = append(columns, hoursColumns[key])
 		}
 
-		// Add rows
-		rows := make([]etable.TableRow, 0, len(hoursEntries))
-		for _, entry := range hoursEntries {
-			project := ""
-			if entry.ProjectID != nil {
-				project = projectsMap[*entry.ProjectID]
-			}
-			rows = append(rows, etable.TableRow{
-				keyId:          entry.ID,
-				keyDate:        entry.Date.Format(dateFormat),
-				keyProject:     project,
-				keyUser:        entry.User,
-				keyHours:       fmt.Sprintf("%.1f h", entry.Hours),
-				keyCreatedTime: entry.Created.Local().Format(timeFormat),
-			})
-		}
-
-		// Render result
-		table := etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows)
-		fmt.Println()
-		fmt.Println(table.Render())
+		// Entries of the previous refresh in watch mode
+		var previousEntries map[string]notion.HoursEntry
 
-		resultLog := fmt.Sprintf("\nFetched %d entries", len(rows))
-		if hoursFetcher.HasMore() {
-			resultLog += ", has more"
-		}
-		ui.PrintlnInfo(resultLog)
+		for {
+			// Create fetcher
+			hoursFetcher := notionClient.NewHoursFetcher(
+				ctx,
+				config.HoursDatabaseID(),
+				filter,
+			)
+			if limit >= 0 {
+				hoursFetcher = hoursFetcher.WithLimit(limit)
+			}
 
-		// Export
-		if outfile, err := cmd.Flags().GetString("outfile"); err != nil {
-			return err
-		} else if outfile != "" {
-			abs, err := filepath.Abs(outfile)
-			if err != nil {
+			// Fetch
+			progress := ui.NewFetchProgress("Fetching hours entries", !quiet)
+			hoursFetcher = hoursFetcher.WithProgress(progress.Update)
+			hoursEntries, err := hoursFetcher.All()
+			progress.Stop()
+			interrupted := hoursFetcher.Interrupted()
+			if err != nil && !interrupted {
 				return err
 			}
-			fd, err := os.Create(abs)
-			if err != nil {
-				return err
+
+			// Resolve relations
+			showTask := slices.Contains(columnKeys, keyTask)
+			showCommission := slices.Contains(columnKeys, keyCommission) || slices.Contains(groupBy, "commission")
+			if (showTask || showCommission) && !interrupted {
+				ids := make([]string, 0)
+				for _, entry := range hoursEntries {
+					if entry.TaskID != nil && showTask {
+						ids = append(ids, *entry.TaskID)
+					}
+					if entry.CommissionID != nil && showCommission {
+						ids = append(ids, *entry.CommissionID)
+					}
+				}
+				if titles, err = notionClient.PageTitles(ctx, ids); err != nil {
+					return err
+				}
 			}
 
-			err = table.ExportCSV(fd)
-			if err != nil {
-				ui.PrintlnfWarn("Could not export to CSV: %s", err.Error())
-			} else {
-				ui.PrintlnfInfo("Data exported to CSV file %s", abs)
+			// Add rows
+			changed := 0
+			rows := make([]etable.TableRow, 0, len(hoursEntries))
+			for _, entry := range hoursEntries {
+				project := ""
+				if entry.ProjectID != nil {
+					project = projectsMap[*entry.ProjectID]
+				}
+				date := ""
+				if !entry.Date.IsZero() {
+					date = entry.Date.Format(dateFormat)
+				}
+				row := etable.TableRow{
+					keyId:          entry.ID,
+					keyDate:        date,
+					keyProject:     project,
+					keyUser:        entry.User,
+					keyHours:       fmt.Sprintf("%.1f h", entry.Hours),
+					keyCreatedTime: entry.Created.Local().Format(timeFormat),
+				}
+				if entry.TaskID != nil {
+					row[keyTask] = titles[*entry.TaskID]
+				}
+				if entry.CommissionID != nil {
+					row[keyCommission] = titles[*entry.CommissionID]
+				}
+				if previousEntries != nil && highlightEntryChanges(row, entry, previousEntries) {
+					changed++
+				}
+				rows = append(rows, row)
 			}
-		}
 
-		// Grouping
-		if grouping, err := cmd.Flags().GetString("group-by"); err != nil {
-			return err
-		} else if grouping != "" {
-			// Define grouping paramteres
-			var groupKey string
-			var groupTitle string
-			var getGroupKeyValue func(entry notion.HoursEntry) string
-
-			switch grouping {
-			case "user":
-				groupKey = keyUser
-				groupTitle = "User"
-				getGroupKeyValue = func(entry notion.HoursEntry) string { return entry.User }
-			case "project":
-				groupKey = keyProject
-				groupTitle = "Project"
-				getGroupKeyValue = func(entry notion.HoursEntry) string {
-					if entry.ProjectID != nil {
-						return projectsMap[*entry.ProjectID]
-					}
-					return ""
+			// Render result
+			table := etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows)
+			if watch > 0 {
+				ui.ClearScreen()
+				ui.PrintlnfInfo(
+					"Every %s, last refresh at %s",
+					watch,
+					time.Now().Format(timeFormat),
+				)
+			}
+			fmt.Println()
+			fmt.Println(table.Render())
+
+			if interrupted {
+				ui.PrintlnfWarn("\nInterrupted, %d rows fetched", len(rows))
+			} else {
+				resultLog := fmt.Sprintf("\nFetched %d entries", len(rows))
+				if hoursFetcher.HasMore() {
+					resultLog += ", has more"
+				}
+				if previousEntries != nil {
+					resultLog += fmt.Sprintf(", %d changed since last refresh", changed)
 				}
+				ui.PrintlnInfo(resultLog)
 			}
 
-			// Define columns
-			columns := []etable.TableColumn{
-				etable.NewTableColumn(groupKey, groupTitle),
-				etable.NewTableColumn(keyEntries, "Entries").WithAlignment(etable.TableAlignmentRight),
-				etable.NewTableColumn(keyHours, "Hours").WithAlignment(etable.TableAlignmentRight),
+			// Grouping
+			var groupColumns []etable.TableColumn
+			var groupRows []etable.TableRow
+			if len(dims) > 0 {
+				groupColumns, groupRows = groupingTable(hoursEntries, dims)
+				groupTable := etable.NewTable(groupColumns).WithStyle(tableStyle).WithRows(groupRows)
+
+				// Render result
+				fmt.Println()
+				fmt.Println(groupTable.Render())
 			}
 
-			// Add rows
-			groupingMap := make(map[string]EntryGroupingValues, 0)
-			for _, entry := range hoursEntries {
-				key := getGroupKeyValue(entry)
-				if r, ok := groupingMap[key]; ok {
-					groupingMap[key] = EntryGroupingValues{
-						Entries: r.Entries + 1,
-						Hours:   r.Hours + entry.Hours,
-					}
+			// Export
+			if outfile, err := cmd.Flags().GetString("outfile"); err != nil {
+				return err
+			} else if outfile != "" && interrupted {
+				ui.PrintlnWarn("Partial results not exported")
+			} else if outfile != "" {
+				abs, err := filepath.Abs(outfile)
+				if err != nil {
+					return err
+				}
+
+				tables := []export.Table{{
+					Name:    "Hours",
+					Columns: columns,
+					Rows:    rows,
+					Kinds: map[string]export.Kind{
+						"Date":    export.KindDate,
+						"Hours":   export.KindHours,
+						"Created": export.KindDatetime,
+					},
+				}}
+				if len(dims) > 0 {
+					tables = append(tables, groupingExport(groupColumns, groupRows, dims))
+				}
+				paths, err := export.Write(abs, tables, config.ExportLayouts())
+				if err != nil {
+					ui.PrintlnfWarn("Could not export data: %s", err.Error())
 				} else {
-					groupingMap[key] = EntryGroupingValues{
-						Entries: 1,
-						Hours:   entry.Hours,
-					}
+					ui.PrintlnfInfo("Data exported to %s", strings.Join(paths, ", "))
 				}
 			}
 
-			rows := make([]etable.TableRow, 0, len(groupingMap))
-			for groupValue, values := range groupingMap {
-				rows = append(rows, etable.TableRow{
-					groupKey:   groupValue,
-					keyEntries: fmt.Sprintf("%d", values.Entries),
-					keyHours:   fmt.Sprintf("%.1f h", values.Hours),
-				})
+			// Partial results exit with the error of the interruption
+			if interrupted {
+				return ctx.Err()
+			}
+			if watch <= 0 {
+				return nil
 			}
 
-			// Render result
-			fmt.Println()
-			fmt.Println(
-				etable.NewTable(columns).WithRows(rows).Render(),
-			)
+			previousEntries = make(map[string]notion.HoursEntry, len(hoursEntries))
+			for _, entry := range hoursEntries {
+				previousEntries[entry.ID] = entry
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(watch):
+			}
 		}
-
-		return nil
 	},
 }
+
+// highlightEntryChanges highlights the values of row that changed with
+// respect to the previous version of the entry, new entries have their date
+// highlighted. Reports whether the entry changed.
+func highlightEntryChanges(
+	row etable.TableRow,
+	entry notion.HoursEntry,
+	previousEntries map[string]notion.HoursEntry,
+) bool {
+	previous, ok := previousEntries[entry.ID]
+	if !ok {
+		row[keyDate] = ui.HighlightStyle.Render(row[keyDate])
+		return true
+	}
+
+	changed := false
+	if previous.User != entry.User {
+		row[keyUser] = ui.HighlightStyle.Render(row[keyUser])
+		changed = true
+	}
+	if previous.Hours != entry.Hours {
+		row[keyHours] = ui.HighlightStyle.Render(row[keyHours])
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,86 @@
+package project
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+)
+
+var ListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list projects with their open tasks and logged hours",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Load config
+		projects := config.Projects()
+		slices.SortFunc(projects, func(a, b notion.Project) int {
+			return compareNames(a.Name, b.Name)
+		})
+
+		// Fetch
+		stats, err := fetchProjectStats(ctx, notionClient, nil)
+		if err != nil {
+			return err
+		}
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		columns := []etable.TableColumn{
+			etable.NewTableColumn(keyProject, "Project"),
+		}
+		for _, status := range config.OpenStatuses() {
+			columns = append(columns, etable.NewTableColumn(status, status).WithAlignment(etable.TableAlignmentRight))
+		}
+		columns = append(
+			columns,
+			etable.NewTableColumn(keyRemaining, "Remaining").WithAlignment(etable.TableAlignmentRight),
+			etable.NewTableColumn(keyWeek, "Week").WithAlignment(etable.TableAlignmentRight),
+			etable.NewTableColumn(keyMonth, "Month").WithAlignment(etable.TableAlignmentRight),
+		)
+
+		// Add rows
+		rows := make([]etable.TableRow, 0, len(projects))
+		for _, project := range projects {
+			s, ok := stats[project.ID]
+			if !ok {
+				s = newProjectStats()
+			}
+			row := etable.TableRow{
+				keyProject:   project.Name,
+				keyRemaining: fmt.Sprintf("%.1f h", s.Remaining),
+				keyWeek:      fmt.Sprintf("%.1f h", s.Week),
+				keyMonth:     fmt.Sprintf("%.1f h", s.Month),
+			}
+			for _, status := range config.OpenStatuses() {
+				row[status] = fmt.Sprintf("%d", s.Statuses[status].Count)
+			}
+			rows = append(rows, row)
+		}
+
+		// Render result
+		fmt.Println()
+		fmt.Println(etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows).Render())
+		ui.PrintlnfInfo("\nFound %d projects", len(projects))
+
+		return nil
+	},
+}
@@ -0,0 +1,188 @@
+package project
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/noty/cmd/task"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/grouping"
+	"github.com/ravvio/noty/notion"
+)
+
+// Table column names
+var (
+	keyProject   = "project"
+	keyStatus    = "status"
+	keyUser      = "user"
+	keyCount     = "count"
+	keyEstimate  = "estimate"
+	keyOpen      = "open"
+	keyRemaining = "remaining"
+	keyWeek      = "week"
+	keyMonth     = "month"
+)
+
+type contributorStats struct {
+	OpenTasks int
+	Week      float64
+	Month     float64
+}
+
+// projectStats summarizes the open tasks and the hours logged on a project.
+type projectStats struct {
+	Tasks        []notion.Task
+	Statuses     map[string]grouping.Stats
+	Remaining    float64
+	OpenTasks    int
+	Week         float64
+	Month        float64
+	Contributors map[string]*contributorStats
+}
+
+func newProjectStats() *projectStats {
+	return &projectStats{
+		Statuses:     make(map[string]grouping.Stats),
+		Contributors: make(map[string]*contributorStats),
+	}
+}
+
+func (stats *projectStats) contributor(user string) *contributorStats {
+	c, ok := stats.Contributors[user]
+	if !ok {
+		c = &contributorStats{}
+		stats.Contributors[user] = c
+	}
+	return c
+}
+
+func init() {
+	ProjectCmd.AddCommand(ListCmd)
+	ProjectCmd.AddCommand(ShowCmd)
+}
+
+var ProjectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "show projects dashboards",
+}
+
+// fetchProjectStats fetches the open tasks of the given projects, or of all
+// projects if none is given, and the hours logged on them this month and
+// this week, and summarizes them by project ID.
+func fetchProjectStats(
+	ctx context.Context,
+	notionClient *notion.Client,
+	projectIDs []string,
+) (map[string]*projectStats, error) {
+	now := time.Now()
+	weekStart := StartOfWeek(now)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	since := monthStart
+	if weekStart.Before(since) {
+		since = weekStart
+	}
+
+	// Fetch open tasks, and hours of this month and of this week if it
+	// started last month
+	var tasks []notion.Task
+	var hoursEntries []notion.HoursEntry
+	group, groupCtx := notionClient.NewGroup(ctx)
+	notion.FetchAll(
+		group,
+		notionClient.NewTaskFetcher(
+			groupCtx,
+			config.TasksDatabaseID(),
+			notion.TaskFilter{
+				Projects: projectIDs,
+				Statuses: config.OpenStatuses(),
+			},
+		),
+		&tasks,
+	)
+	notion.FetchAll(
+		group,
+		notionClient.NewHoursFetcher(
+			groupCtx,
+			config.HoursDatabaseID(),
+			notion.HoursFilter{
+				Projects: projectIDs,
+				Date: notion.HoursDateSince{
+					Date: since,
+				},
+			},
+		),
+		&hoursEntries,
+	)
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]*projectStats)
+	for _, id := range projectIDs {
+		stats[id] = newProjectStats()
+	}
+	projectStatsOf := func(projectID *string) *projectStats {
+		if projectID == nil {
+			return nil
+		}
+		s, ok := stats[*projectID]
+		if !ok && len(projectIDs) == 0 {
+			s = newProjectStats()
+			stats[*projectID] = s
+		}
+		return s
+	}
+
+	for _, t := range tasks {
+		s := projectStatsOf(t.ProjectID)
+		if s == nil {
+			continue
+		}
+		s.Tasks = append(s.Tasks, t)
+		s.Remaining += t.Estimate
+		s.OpenTasks++
+		s.contributor(t.Assignee).OpenTasks++
+	}
+
+	// Estimates per status
+	byStatus := []grouping.Dimension[notion.Task]{
+		task.TaskDimensions(config.ProjectsMap(), nil)["status"],
+	}
+	for _, s := range stats {
+		for _, group := range grouping.By(s.Tasks, byStatus).Groups {
+			s.Statuses[group.Value] = task.EstimateStats(group.Items)
+		}
+	}
+
+	for _, entry := range hoursEntries {
+		s := projectStatsOf(entry.ProjectID)
+		if s == nil {
+			continue
+		}
+		c := s.contributor(entry.User)
+		if !entry.Date.Before(weekStart) {
+			s.Week += entry.Hours
+			c.Week += entry.Hours
+		}
+		if !entry.Date.Before(monthStart) {
+			s.Month += entry.Hours
+			c.Month += entry.Hours
+		}
+	}
+
+	return stats, nil
+}
+
+func compareNames(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// StartOfWeek returns the Monday of the week of t, as a UTC date.
+func StartOfWeek(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,119 @@
+package project
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/utils"
+)
+
+func init() {
+	ShowCmd.Flags().IntP("top", "t", 5, "number of top contributors to show")
+}
+
+var ShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "show the dashboard of a project",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Top Flag
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+
+		project, err := config.ParseProject(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Fetch
+		stats, err := fetchProjectStats(ctx, notionClient, []string{project.ID})
+		if err != nil {
+			return err
+		}
+		s := stats[project.ID]
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		// Status table
+		statusColumns := []etable.TableColumn{
+			etable.NewTableColumn(keyStatus, "Status"),
+			etable.NewTableColumn(keyCount, "Count").WithAlignment(etable.TableAlignmentRight),
+			etable.NewTableColumn(keyEstimate, "Estimate").WithAlignment(etable.TableAlignmentRight),
+		}
+		statusRows := make([]etable.TableRow, 0, len(config.OpenStatuses()))
+		for _, status := range config.OpenStatuses() {
+			values := s.Statuses[status]
+			statusRows = append(statusRows, etable.TableRow{
+				keyStatus:   status,
+				keyCount:    fmt.Sprintf("%d", values.Count),
+				keyEstimate: fmt.Sprintf("%.1f h", values.Sum),
+			})
+		}
+
+		// Contributors table, by hours logged this month
+		users := utils.MapKeys(s.Contributors)
+		slices.SortFunc(users, func(a, b string) int {
+			ca, cb := s.Contributors[a], s.Contributors[b]
+			if c := cmp.Compare(cb.Month, ca.Month); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(cb.OpenTasks, ca.OpenTasks); c != 0 {
+				return c
+			}
+			return compareNames(a, b)
+		})
+		if top >= 0 && len(users) > top {
+			users = users[:top]
+		}
+
+		contributorColumns := []etable.TableColumn{
+			etable.NewTableColumn(keyUser, "User"),
+			etable.NewTableColumn(keyOpen, "Open Tasks").WithAlignment(etable.TableAlignmentRight),
+			etable.NewTableColumn(keyWeek, "Week").WithAlignment(etable.TableAlignmentRight),
+			etable.NewTableColumn(keyMonth, "Month").WithAlignment(etable.TableAlignmentRight),
+		}
+		contributorRows := make([]etable.TableRow, 0, len(users))
+		for _, user := range users {
+			c := s.Contributors[user]
+			contributorRows = append(contributorRows, etable.TableRow{
+				keyUser:  user,
+				keyOpen:  fmt.Sprintf("%d", c.OpenTasks),
+				keyWeek:  fmt.Sprintf("%.1f h", c.Week),
+				keyMonth: fmt.Sprintf("%.1f h", c.Month),
+			})
+		}
+
+		// Render result
+		fmt.Printf("\n%s\n\n", project.Name)
+		fmt.Printf("Open tasks: %d (%.1f h remaining)\n", s.OpenTasks, s.Remaining)
+		fmt.Printf("Hours logged: %.1f h this week, %.1f h this month\n", s.Week, s.Month)
+		fmt.Println()
+		fmt.Println(etable.NewTable(statusColumns).WithStyle(tableStyle).WithRows(statusRows).Render())
+		fmt.Println()
+		fmt.Println(etable.NewTable(contributorColumns).WithStyle(tableStyle).WithRows(contributorRows).Render())
+
+		return nil
+	},
+}
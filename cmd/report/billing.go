@@ -0,0 +1,325 @@
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/export"
+	"github.com/ravvio/noty/flags"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+	"github.com/ravvio/noty/utils"
+)
+
+// Table column names
+var (
+	keyCommission = "commission"
+	keyUser       = "user"
+	keyEntries    = "entries"
+	keyHours      = "hours"
+	keyDays       = "days"
+)
+
+// Layout of the month flag
+const monthLayout = "2006-01"
+
+// Name of subtotal and total rows
+const totalName = "Total"
+
+type billingValues struct {
+	Entries int
+	Hours   float64
+	Days    float64
+}
+
+func (v *billingValues) add(other billingValues) {
+	v.Entries += other.Entries
+	v.Hours += other.Hours
+	v.Days += other.Days
+}
+
+func (v billingValues) row(commission string, user string) etable.TableRow {
+	return etable.TableRow{
+		keyCommission: commission,
+		keyUser:       user,
+		keyEntries:    fmt.Sprintf("%d", v.Entries),
+		keyHours:      fmt.Sprintf("%.1f h", v.Hours),
+		keyDays:       fmt.Sprintf("%.2f", v.Days),
+	}
+}
+
+var billingColumns = []etable.TableColumn{
+	etable.NewTableColumn(keyCommission, "Commission"),
+	etable.NewTableColumn(keyUser, "User").WithStyleFunc(
+		func(style lipgloss.Style, value string) lipgloss.Style {
+			if value == totalName {
+				return style.Bold(true)
+			}
+			return style
+		},
+	),
+	etable.NewTableColumn(keyEntries, "Entries").WithAlignment(etable.TableAlignmentRight),
+	etable.NewTableColumn(keyHours, "Hours").WithAlignment(etable.TableAlignmentRight),
+	etable.NewTableColumn(keyDays, "Days").WithAlignment(etable.TableAlignmentRight),
+}
+
+func init() {
+	BillingCmd.Flags().Var(
+		flags.StringChoiceOrDate(
+			[]string{"current", "last"},
+			"current",
+			func() string { return monthLayout },
+		),
+		"month",
+		"month to report [current, last, YYYY-MM]",
+	)
+	BillingCmd.Flags().StringSliceP("commission", "c", []string{}, "report only the given commission(s)")
+	BillingCmd.Flags().StringSliceP("users", "u", []string{}, "report only the given users")
+	BillingCmd.Flags().StringP("outfile", "o", "", "export result as xlsx, json, md or csv, by extension")
+}
+
+var BillingCmd = &cobra.Command{
+	Use:   "billing",
+	Short: "report the hours worked in a month per commission and user",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Load config
+		dateFormat := config.DateFormat()
+
+		// Month Flag
+		monthFlag, err := cmd.Flags().GetString("month")
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		switch monthFlag {
+		case "current":
+		case "last":
+			start = start.AddDate(0, -1, 0)
+		default:
+			if start, err = time.Parse(monthLayout, monthFlag); err != nil {
+				return err
+			}
+		}
+		end := start.AddDate(0, 1, -1)
+
+		// Create filter
+		filter := notion.HoursFilter{
+			Date: notion.HoursDateBetween{
+				Start: start,
+				End:   end,
+			},
+		}
+
+		// Commissions Flag
+		if commissions, err := cmd.Flags().GetStringSlice("commission"); err != nil {
+			return err
+		} else if len(commissions) > 0 {
+			commissions, err := config.ParseCommissions(ctx, commissions)
+			if err != nil {
+				return err
+			}
+			for _, commission := range commissions {
+				filter.Commissions = append(filter.Commissions, commission.ID)
+			}
+		}
+
+		// Users Flag
+		if users, err := cmd.Flags().GetStringSlice("users"); err != nil {
+			return err
+		} else if len(users) > 0 {
+			users, err := config.ParseUsers(ctx, users)
+			if err != nil {
+				return err
+			}
+			for _, user := range users {
+				filter.Users = append(filter.Users, user.ID)
+			}
+		}
+
+		quiet, err := cmd.Flags().GetBool("quiet")
+		if err != nil {
+			return err
+		}
+
+		// Fetch
+		hoursFetcher := notionClient.NewHoursFetcher(
+			ctx,
+			config.HoursDatabaseID(),
+			filter,
+		)
+		progress := ui.NewFetchProgress("Fetching hours entries", !quiet)
+		hoursFetcher = hoursFetcher.WithProgress(progress.Update)
+		entries, err := hoursFetcher.All()
+		progress.Stop()
+		if err != nil {
+			return err
+		}
+
+		// Commission names, the ones missing from the cache are fetched
+		names := config.CommissionsMap()
+		missing := make([]string, 0)
+		for _, entry := range entries {
+			if entry.CommissionID == nil {
+				continue
+			}
+			if _, ok := names[*entry.CommissionID]; !ok {
+				missing = append(missing, *entry.CommissionID)
+			}
+		}
+		if len(missing) > 0 {
+			titles, err := notionClient.PageTitles(ctx, missing)
+			if err != nil {
+				return err
+			}
+			for id, title := range titles {
+				names[id] = title
+			}
+		}
+
+		capacities, err := config.Capacities(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Hours per commission ID per user, entries without commission
+		// under an empty ID
+		billing := make(map[string]map[string]billingValues)
+		for _, entry := range entries {
+			commission := ""
+			if entry.CommissionID != nil {
+				commission = *entry.CommissionID
+			}
+			if _, ok := billing[commission]; !ok {
+				billing[commission] = make(map[string]billingValues)
+			}
+			hoursPerDay, ok := capacities[entry.UserID]
+			if !ok {
+				hoursPerDay = config.HoursPerDay()
+			}
+			values := billing[commission][entry.User]
+			values.add(billingValues{
+				Entries: 1,
+				Hours:   entry.Hours,
+				Days:    entry.Hours / hoursPerDay,
+			})
+			billing[commission][entry.User] = values
+		}
+
+		// Names shown for commissions, the ID of the ones without title
+		commissionName := func(id string) string {
+			switch {
+			case id == "":
+				return "No commission"
+			case names[id] != "":
+				return names[id]
+			}
+			return id
+		}
+
+		// Entries without commission go last
+		commissions := utils.MapKeys(billing)
+		slices.SortFunc(commissions, func(a, b string) int {
+			if (a == "") != (b == "") {
+				if a == "" {
+					return 1
+				}
+				return -1
+			}
+			return cmp.Or(
+				strings.Compare(strings.ToLower(commissionName(a)), strings.ToLower(commissionName(b))),
+				strings.Compare(a, b),
+			)
+		})
+
+		// Add rows
+		rows := make([]etable.TableRow, 0)
+		total := billingValues{}
+		for _, commission := range commissions {
+			name := commissionName(commission)
+
+			users := utils.MapKeys(billing[commission])
+			slices.Sort(users)
+			subtotal := billingValues{}
+			for _, user := range users {
+				values := billing[commission][user]
+				rows = append(rows, values.row(name, user))
+				subtotal.add(values)
+			}
+			rows = append(rows, subtotal.row(name, totalName))
+			total.add(subtotal)
+		}
+		rows = append(rows, total.row(totalName, ""))
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		// Render result
+		table := etable.NewTable(billingColumns).WithStyle(tableStyle).WithRows(rows)
+		fmt.Printf(
+			"\nBilling of %s (%s - %s)\n\n",
+			start.Format("January 2006"),
+			start.Format(dateFormat),
+			end.Format(dateFormat),
+		)
+		fmt.Println(table.Render())
+		reported := len(commissions)
+		if _, ok := billing[""]; ok {
+			reported--
+		}
+		ui.PrintlnfInfo("\nReported %d entries in %d commissions", len(entries), reported)
+
+		// Export
+		if outfile, err := cmd.Flags().GetString("outfile"); err != nil {
+			return err
+		} else if outfile != "" {
+			abs, err := filepath.Abs(outfile)
+			if err != nil {
+				return err
+			}
+			paths, err := export.Write(
+				abs,
+				[]export.Table{{
+					Name:    "Billing",
+					Columns: billingColumns,
+					Rows:    rows,
+					Kinds: map[string]export.Kind{
+						"Entries": export.KindInt,
+						"Hours":   export.KindHours,
+						"Days":    export.KindFloat,
+					},
+				}},
+				config.ExportLayouts(),
+			)
+			if err != nil {
+				ui.PrintlnfWarn("Could not export data: %s", err.Error())
+			} else {
+				ui.PrintlnfInfo("Data exported to %s", strings.Join(paths, ", "))
+			}
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,430 @@
+package report
+
+import (
+	"cmp"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/cmd/task"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/export"
+	"github.com/ravvio/noty/flags"
+	"github.com/ravvio/noty/grouping"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+	"github.com/ravvio/noty/utils"
+)
+
+//go:embed html.tmpl
+var htmlSource string
+
+var htmlTemplate = template.Must(template.New("report").Parse(htmlSource))
+
+// Cells aligned to the right and sorted by value, e.g. 3.5 h or 80%
+var numericRegexp = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?( h|%)?$`)
+
+// Sizes of the bar charts, in pixels
+const (
+	chartLabelWidth = 140
+	chartBarWidth   = 360
+	chartRowHeight  = 24
+)
+
+type htmlReport struct {
+	Title     string
+	Dates     string
+	Generated string
+	Colors    map[string]string
+	Stats     []htmlStat
+	Charts    []htmlChart
+	Tables    []htmlTable
+}
+
+type htmlStat struct {
+	Label string
+	Value string
+}
+
+type htmlTable struct {
+	Title  string
+	Header []htmlCell
+	Rows   [][]htmlCell
+	Totals [][]htmlCell
+}
+
+type htmlCell struct {
+	Text    string
+	Sort    string
+	URL     string
+	Color   string
+	Numeric bool
+}
+
+type htmlChart struct {
+	Title  string
+	Width  int
+	Height int
+	Bars   []htmlBar
+}
+
+type htmlBar struct {
+	Label string
+	Value string
+	Color string
+	Y     int
+	Width int
+}
+
+func init() {
+	HTMLCmd.Flags().Var(
+		flags.StringChoiceOrInt([]string{"current", "next"}, "current"),
+		"sprint",
+		"sprint to report [current, next, sprint number]",
+	)
+	HTMLCmd.Flags().VarP(
+		flags.StringChoiceSlice(task.GroupChoices, []string{"assignee", "status"}),
+		"group-by",
+		"g",
+		fmt.Sprintf("summarize tasks by each of the given dimensions %v", task.GroupChoices),
+	)
+	HTMLCmd.Flags().StringP("outfile", "o", "sprint.html", "file to write the report to")
+}
+
+var HTMLCmd = &cobra.Command{
+	Use:   "html",
+	Short: "write a sprint report to a self-contained html file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Load config
+		dateFormat := config.DateFormat()
+		projectsMap := config.ProjectsMap()
+
+		// Outfile Flag
+		outfile, err := cmd.Flags().GetString("outfile")
+		if err != nil {
+			return err
+		}
+		outfile, err = filepath.Abs(outfile)
+		if err != nil {
+			return err
+		}
+
+		// Grouping Flag
+		var sprintTitles map[string]string
+		dimensions := task.TaskDimensions(
+			projectsMap,
+			func(ids []string) string { return notion.JoinTitles(ids, sprintTitles) },
+		)
+		groupBy, err := cmd.Flags().GetStringSlice("group-by")
+		if err != nil {
+			return err
+		}
+		dims, err := task.ParseGrouping(groupBy, dimensions)
+		if err != nil {
+			return err
+		}
+
+		// Sprint Flag
+		sprintFlag, err := cmd.Flags().GetString("sprint")
+		if err != nil {
+			return err
+		}
+		sprint, err := task.FetchSprint(ctx, notionClient, sprintFlag)
+		if err != nil {
+			return err
+		}
+		if sprint.Dates.Start.IsZero() {
+			return fmt.Errorf("sprint '%s' has no dates", sprint.Name)
+		}
+
+		// Fetch tasks of the sprint and hours logged during it, the ones
+		// logged on other tasks are dropped once tasks are known
+		var tasks []notion.Task
+		var hoursEntries []notion.HoursEntry
+		group, groupCtx := notionClient.NewGroup(ctx)
+		notion.FetchAll(
+			group,
+			notionClient.NewTaskFetcher(
+				groupCtx,
+				config.TasksDatabaseID(),
+				notion.TaskFilter{
+					Sprint: notion.TaskSprintByID{ID: sprint.ID},
+				},
+			),
+			&tasks,
+		)
+		notion.FetchAll(
+			group,
+			notionClient.NewHoursFetcher(
+				groupCtx,
+				config.HoursDatabaseID(),
+				notion.HoursFilter{
+					Date: notion.HoursDateBetween{
+						Start: sprint.Dates.Start,
+						End:   sprint.Dates.End,
+					},
+				},
+			),
+			&hoursEntries,
+		)
+		if err := group.Wait(); err != nil {
+			return err
+		}
+		taskIDs := make(map[string]bool, len(tasks))
+		for _, t := range tasks {
+			taskIDs[t.ID] = true
+		}
+		hoursEntries = slices.DeleteFunc(hoursEntries, func(entry notion.HoursEntry) bool {
+			return entry.TaskID == nil || !taskIDs[*entry.TaskID]
+		})
+
+		// Resolve relations
+		if slices.Contains(groupBy, "sprint") {
+			ids := make([]string, 0)
+			for _, task := range tasks {
+				ids = append(ids, task.SprintIDs...)
+			}
+			if sprintTitles, err = notionClient.PageTitles(ctx, ids); err != nil {
+				return err
+			}
+		}
+
+		// Stats
+		estimate, completed := 0.0, 0.0
+		for _, t := range tasks {
+			estimate += t.Estimate
+			if config.HasStatusRole(t.Status, config.RoleDone) {
+				completed += t.Estimate
+			}
+		}
+		hours := make(map[string]float64)
+		totalHours := 0.0
+		for _, entry := range hoursEntries {
+			hours[entry.User] += entry.Hours
+			totalHours += entry.Hours
+		}
+
+		report := htmlReport{
+			Title:     sprint.Name,
+			Dates:     fmt.Sprintf("%s - %s", sprint.Dates.Start.Format(dateFormat), sprint.Dates.End.Format(dateFormat)),
+			Generated: time.Now().Format(config.DatetimeFormat()),
+			Colors: map[string]string{
+				"primary": ui.HexOf(ui.Primary),
+				"dim":     ui.HexOf(ui.DimFg),
+				"accent":  ui.HexOf(ui.Accent),
+			},
+			Stats: []htmlStat{
+				{Label: "Tasks", Value: fmt.Sprintf("%d", len(tasks))},
+				{Label: "Estimate", Value: fmt.Sprintf("%.1f h", estimate)},
+				{Label: "Completed", Value: fmt.Sprintf("%.1f h", completed)},
+				{Label: "Hours logged", Value: fmt.Sprintf("%.1f h", totalHours)},
+			},
+		}
+
+		// Charts
+		userBars := make([]htmlBar, 0, len(hours))
+		users := utils.MapKeys(hours)
+		slices.SortFunc(users, func(a, b string) int {
+			return cmp.Or(cmp.Compare(hours[b], hours[a]), grouping.CompareNatural(a, b))
+		})
+		for _, user := range users {
+			userBars = append(userBars, htmlBar{
+				Label: user,
+				Value: fmt.Sprintf("%.1f h", hours[user]),
+				Color: ui.HexOf(ui.Primary),
+			})
+		}
+		report.Charts = append(report.Charts, newChart("Hours per user", userBars, func(i int) float64 {
+			return hours[users[i]]
+		}))
+
+		byStatus := grouping.By(tasks, []grouping.Dimension[notion.Task]{dimensions["status"]})
+		statusBars := make([]htmlBar, 0, len(byStatus.Groups))
+		statusEstimates := make([]float64, 0, len(byStatus.Groups))
+		for _, g := range byStatus.Groups {
+			sum := 0.0
+			for _, t := range g.Items {
+				sum += t.Estimate
+			}
+			statusBars = append(statusBars, htmlBar{
+				Label: g.Value,
+				Value: fmt.Sprintf("%.1f h", sum),
+				Color: statusHex(g.Value),
+			})
+			statusEstimates = append(statusEstimates, sum)
+		}
+		report.Charts = append(report.Charts, newChart("Estimate per status", statusBars, func(i int) float64 {
+			return statusEstimates[i]
+		}))
+
+		// Tasks table
+		slices.SortFunc(tasks, func(a, b notion.Task) int {
+			return cmp.Compare(a.StoryID, b.StoryID)
+		})
+		tasksTable := htmlTable{
+			Title: "Tasks",
+			Header: []htmlCell{
+				{Text: "Story ID"},
+				{Text: "Name"},
+				{Text: "Project"},
+				{Text: "Assignee"},
+				{Text: "Reviewer"},
+				{Text: "Status"},
+				{Text: "Priority"},
+				{Text: "Estimate", Numeric: true},
+			},
+		}
+		for _, t := range tasks {
+			project := ""
+			if t.ProjectID != nil {
+				project = projectsMap[*t.ProjectID]
+			}
+			tasksTable.Rows = append(tasksTable.Rows, []htmlCell{
+				{Text: fmt.Sprintf("STORY-%d", t.StoryID), Sort: fmt.Sprintf("%d", t.StoryID), Numeric: true},
+				{Text: t.Name, URL: t.URL},
+				{Text: project},
+				{Text: t.Assignee},
+				{Text: t.Reviewer},
+				{Text: t.Status, Sort: statusOrder(t.Status), Color: statusHex(t.Status)},
+				{Text: t.Priority, Sort: priorityOrder(t.Priority), Color: priorityHex(t.Priority)},
+				{Text: fmt.Sprintf("%.1f", t.Estimate), Numeric: true},
+			})
+		}
+		report.Tables = append(report.Tables, tasksTable)
+
+		// Grouping summaries
+		for _, dim := range dims {
+			dims := []grouping.Dimension[notion.Task]{dim}
+			columns, rows := task.GroupingTable(tasks, dims)
+			summary, err := newHTMLTable(
+				fmt.Sprintf("Tasks by %s", dim.Title),
+				etable.NewTable(columns).WithRows(rows),
+			)
+			if err != nil {
+				return err
+			}
+			report.Tables = append(report.Tables, summary)
+		}
+
+		// Export
+		err = utils.WriteFileAtomic(outfile, func(w io.Writer) error {
+			return htmlTemplate.Execute(w, report)
+		})
+		if err != nil {
+			return err
+		}
+		ui.PrintlnfInfo("Reported %d tasks and %d hours entries to %s", len(tasks), len(hoursEntries), outfile)
+
+		return nil
+	},
+}
+
+// newChart returns a bar chart of bars, with lengths scaled to the maximum
+// of the values returned by value for each bar.
+func newChart(title string, bars []htmlBar, value func(i int) float64) htmlChart {
+	maxValue := 0.0
+	for i := range bars {
+		maxValue = max(maxValue, value(i))
+	}
+	for i := range bars {
+		bars[i].Y = i * chartRowHeight
+		if maxValue > 0 {
+			bars[i].Width = max(1, int(value(i)/maxValue*chartBarWidth))
+		}
+	}
+	return htmlChart{
+		Title:  title,
+		Width:  chartLabelWidth + chartBarWidth + 80,
+		Height: max(1, len(bars)) * chartRowHeight,
+		Bars:   bars,
+	}
+}
+
+// newHTMLTable returns the cells of table, with the rows of totals apart so
+// that they stay last when sorting.
+func newHTMLTable(title string, table *etable.Table) (htmlTable, error) {
+	header, rows, err := export.Rows(table)
+	if err != nil {
+		return htmlTable{}, err
+	}
+
+	t := htmlTable{Title: title}
+	for _, text := range header {
+		t.Header = append(t.Header, htmlCell{Text: text, Color: statusHex(text)})
+	}
+	for _, row := range rows {
+		cells := make([]htmlCell, 0, len(row))
+		for i, text := range row {
+			cell := htmlCell{Text: text, Numeric: numericRegexp.MatchString(text)}
+			switch header[i] {
+			case "Status":
+				cell.Color = statusHex(text)
+				cell.Sort = statusOrder(text)
+			case "Priority":
+				cell.Color = priorityHex(text)
+				cell.Sort = priorityOrder(text)
+			}
+			cells = append(cells, cell)
+		}
+		if len(row) > 0 && row[0] == grouping.TotalName {
+			t.Totals = append(t.Totals, cells)
+		} else {
+			t.Rows = append(t.Rows, cells)
+		}
+	}
+	for i := range t.Header {
+		t.Header[i].Numeric = len(t.Rows) > 0 && t.Rows[0][i].Numeric
+	}
+	return t, nil
+}
+
+// statusHex returns the color of a configured status, empty for other values.
+func statusHex(status string) string {
+	for _, s := range config.Statuses() {
+		if s.Name == status {
+			if color, ok := ui.ColorOf(s.Color); ok {
+				return ui.HexOf(color)
+			}
+		}
+	}
+	return ""
+}
+
+// statusOrder returns the position of a status in the configured order, as
+// sort key.
+func statusOrder(status string) string {
+	for i, s := range config.Statuses() {
+		if s.Name == status {
+			return fmt.Sprintf("%d", i)
+		}
+	}
+	return ""
+}
+
+// priorityHex returns the color of a priority, empty for other values.
+func priorityHex(priority string) string {
+	if color, ok := task.PriorityColor(priority); ok {
+		return ui.HexOf(color)
+	}
+	return ""
+}
+
+// priorityOrder returns the position of a priority in the task order, as
+// sort key.
+func priorityOrder(priority string) string {
+	if i := slices.Index(task.PriorityOrder, priority); i >= 0 {
+		return fmt.Sprintf("%d", i)
+	}
+	return ""
+}
@@ -0,0 +1,16 @@
+package report
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	ReportCmd.AddCommand(VelocityCmd)
+	ReportCmd.AddCommand(BillingCmd)
+	ReportCmd.AddCommand(HTMLCmd)
+}
+
+var ReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "generate reports on tasks, sprints and hours",
+}
@@ -0,0 +1,257 @@
+package report
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/cmd/task"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/grouping"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+)
+
+// Table column names
+var (
+	keySprint            = "sprint"
+	keyTasks             = "tasks"
+	keyPlanned           = "planned"
+	keyCompleted         = "completed"
+	keyCompletedEstimate = "completedEstimate"
+	keyCarryOver         = "carryOver"
+	keyAssignee          = "assignee"
+	keyTotal             = "total"
+	keyTrend             = "trend"
+)
+
+type sprintVelocity struct {
+	Sprint    notion.Sprint
+	Planned   grouping.Stats
+	Completed grouping.Stats
+	CarryOver int
+}
+
+func init() {
+	VelocityCmd.Flags().IntP("last", "n", 6, "number of sprints to analyze")
+	VelocityCmd.Flags().Bool("include-current", false, "include the current sprint in the analysis")
+}
+
+var VelocityCmd = &cobra.Command{
+	Use:   "velocity",
+	Short: "analyze team velocity and throughput across sprints",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Last Flag
+		last, err := cmd.Flags().GetInt("last")
+		if err != nil {
+			return err
+		}
+		if last <= 0 {
+			return fmt.Errorf("the number of sprints must be positive")
+		}
+		includeCurrent, err := cmd.Flags().GetBool("include-current")
+		if err != nil {
+			return err
+		}
+
+		// Fetch sprints
+		sprintFetcher := notionClient.NewSprintFetcher(
+			ctx,
+			config.SprintsDatabaseID(),
+			notion.SprintFilter{},
+		)
+		sprints, err := sprintFetcher.All()
+		if err != nil {
+			return err
+		}
+		slices.SortFunc(sprints, func(a, b notion.Sprint) int {
+			return a.SprintID - b.SprintID
+		})
+
+		// Select the sprints to analyze, the ones before the current one
+		end := len(sprints)
+		if i := slices.IndexFunc(sprints, func(s notion.Sprint) bool { return s.Status == "Current" }); i >= 0 {
+			end = i
+			if includeCurrent {
+				end++
+			}
+		}
+		window := sprints[max(0, end-last):end]
+		if len(window) == 0 {
+			return fmt.Errorf("no sprint found")
+		}
+
+		sprintIDs := make([]string, 0, len(window))
+		for _, s := range window {
+			sprintIDs = append(sprintIDs, s.ID)
+		}
+
+		// Order of all sprints, used to find the last sprint of a task
+		sprintOrder := make(map[string]int, len(sprints))
+		for i, s := range sprints {
+			sprintOrder[s.ID] = i
+		}
+
+		// Fetch tasks
+		taskFetcher := notionClient.NewTaskFetcher(
+			ctx,
+			config.TasksDatabaseID(),
+			notion.TaskFilter{
+				Sprint: notion.TaskSprintByIDs{
+					SprintIDs: sprintIDs,
+				},
+			},
+		)
+		tasks, err := taskFetcher.All()
+		if err != nil {
+			return err
+		}
+
+		// Compute velocity, completed tasks count in their last sprint
+		velocities := make([]sprintVelocity, 0, len(window))
+		done := make([]notion.Task, 0)
+		for _, s := range window {
+			v := sprintVelocity{Sprint: s}
+
+			next := ""
+			if i := sprintOrder[s.ID] + 1; i < len(sprints) {
+				next = sprints[i].ID
+			}
+
+			planned := make([]notion.Task, 0)
+			completed := make([]notion.Task, 0)
+			for _, t := range tasks {
+				if !slices.Contains(t.SprintIDs, s.ID) {
+					continue
+				}
+				planned = append(planned, t)
+
+				if next != "" && slices.Contains(t.SprintIDs, next) {
+					v.CarryOver++
+				}
+				if config.HasStatusRole(t.Status, config.RoleDone) && lastSprint(t, sprintOrder) == s.ID {
+					completed = append(completed, t)
+				}
+			}
+			v.Planned = task.EstimateStats(planned)
+			v.Completed = task.EstimateStats(completed)
+			done = append(done, completed...)
+			velocities = append(velocities, v)
+		}
+
+		// Estimates completed per assignee and sprint
+		throughputDims := []grouping.Dimension[notion.Task]{
+			task.TaskDimensions(config.ProjectsMap(), nil)["assignee"],
+			{
+				Key:   keySprint,
+				Title: "Sprint",
+				Value: func(t notion.Task) string { return lastSprint(t, sprintOrder) },
+			},
+		}
+		throughput := grouping.By(done, throughputDims)
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		// Velocity table
+		columns := []etable.TableColumn{
+			etable.NewTableColumn(keySprint, "Sprint"),
+			etable.NewTableColumn(keyTasks, "Tasks").WithAlignment(etable.TableAlignmentRight),
+			etable.NewTableColumn(keyPlanned, "Planned").WithAlignment(etable.TableAlignmentRight),
+			etable.NewTableColumn(keyCompleted, "Completed").WithAlignment(etable.TableAlignmentRight),
+			etable.NewTableColumn(keyCompletedEstimate, "Completed Estimate").WithAlignment(etable.TableAlignmentRight),
+			etable.NewTableColumn(keyCarryOver, "Carry-over").WithAlignment(etable.TableAlignmentRight),
+		}
+
+		rows := make([]etable.TableRow, 0, len(velocities))
+		trend := make([]float64, 0, len(velocities))
+		total := 0.0
+		for _, v := range velocities {
+			rows = append(rows, etable.TableRow{
+				keySprint:            v.Sprint.Name,
+				keyTasks:             fmt.Sprintf("%d", v.Planned.Count),
+				keyPlanned:           fmt.Sprintf("%.1f h", v.Planned.Sum),
+				keyCompleted:         fmt.Sprintf("%d", v.Completed.Count),
+				keyCompletedEstimate: fmt.Sprintf("%.1f h", v.Completed.Sum),
+				keyCarryOver:         fmt.Sprintf("%d", v.CarryOver),
+			})
+			trend = append(trend, v.Completed.Sum)
+			total += v.Completed.Sum
+		}
+
+		fmt.Println()
+		fmt.Println(etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows).Render())
+		fmt.Printf(
+			"\nTrend %s, average %.1f h per sprint\n",
+			ui.Sparkline(trend),
+			total/float64(len(velocities)),
+		)
+
+		// Throughput table
+		columns = []etable.TableColumn{
+			etable.NewTableColumn(keyAssignee, "Assignee"),
+		}
+		for _, s := range window {
+			columns = append(columns, etable.NewTableColumn(s.ID, s.Name).WithAlignment(etable.TableAlignmentRight))
+		}
+		columns = append(
+			columns,
+			etable.NewTableColumn(keyTotal, "Total").WithAlignment(etable.TableAlignmentRight),
+			etable.NewTableColumn(keyTrend, "Trend"),
+		)
+
+		rows = make([]etable.TableRow, 0, len(throughput.Groups))
+		for _, assignee := range throughput.Groups {
+			row := etable.TableRow{
+				keyAssignee: assignee.Value,
+			}
+			hours := make(map[string]float64)
+			for _, sprint := range assignee.Groups {
+				hours[sprint.Value] = task.EstimateStats(sprint.Items).Sum
+			}
+			trend := make([]float64, 0, len(window))
+			total := 0.0
+			for _, s := range window {
+				row[s.ID] = fmt.Sprintf("%.1f h", hours[s.ID])
+				trend = append(trend, hours[s.ID])
+				total += hours[s.ID]
+			}
+			row[keyTotal] = fmt.Sprintf("%.1f h", total)
+			row[keyTrend] = ui.Sparkline(trend)
+			rows = append(rows, row)
+		}
+
+		fmt.Println()
+		fmt.Println(etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows).Render())
+
+		ui.PrintlnfInfo("\nAnalyzed %d tasks in %d sprints", len(tasks), len(window))
+
+		return nil
+	},
+}
+
+// lastSprint returns the most recent sprint a task belongs to.
+func lastSprint(t notion.Task, sprintOrder map[string]int) string {
+	last := ""
+	for _, id := range t.SprintIDs {
+		if order, ok := sprintOrder[id]; ok && (last == "" || order > sprintOrder[last]) {
+			last = id
+		}
+	}
+	return last
+}
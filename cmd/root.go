@@ -1,20 +1,56 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
+	"github.com/ravvio/noty/cmd/activity"
 	"github.com/ravvio/noty/cmd/configure"
 	"github.com/ravvio/noty/cmd/hours"
+	"github.com/ravvio/noty/cmd/project"
+	"github.com/ravvio/noty/cmd/report"
+	"github.com/ravvio/noty/cmd/search"
+	"github.com/ravvio/noty/cmd/sprint"
+	"github.com/ravvio/noty/cmd/standup"
 	"github.com/ravvio/noty/cmd/task"
+	"github.com/ravvio/noty/cmd/user"
 	"github.com/ravvio/noty/config"
 	"github.com/ravvio/noty/flags"
+	"github.com/ravvio/noty/notion"
 	"github.com/ravvio/noty/ui"
 	"github.com/spf13/cobra"
 )
 
+// Cancels the context of the --timeout flag
+var cancelTimeout context.CancelFunc = func() {}
+
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		// Restore the default behavior, a second interrupt kills the process
+		<-ctx.Done()
+		stop()
+	}()
+
+	// Flags validated with configured layouts need the configuration loaded
+	// before parsing, errors are reported when the command loads it
+	_, _ = config.Load()
+
+	err := rootCmd.ExecuteContext(ctx)
+	interrupted := ctx.Err() != nil
+	cancelTimeout()
+	stop()
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		err = fmt.Errorf("timed out, see the --timeout flag")
+	case errors.Is(err, context.Canceled) && interrupted:
+		err = fmt.Errorf("interrupted")
+	}
 	if err != nil {
 		ui.PrintlnfError("Error: %s", err)
 		os.Exit(1)
@@ -29,8 +65,16 @@ func init() {
 	}
 
 	rootCmd.AddCommand(configure.ConfigCmd)
+	rootCmd.AddCommand(configure.ConfigFileCmd)
 	rootCmd.AddCommand(task.TaskCmd)
 	rootCmd.AddCommand(hours.HoursCmd)
+	rootCmd.AddCommand(search.SearchCmd)
+	rootCmd.AddCommand(activity.ActivityCmd)
+	rootCmd.AddCommand(standup.StandupCmd)
+	rootCmd.AddCommand(report.ReportCmd)
+	rootCmd.AddCommand(sprint.SprintCmd)
+	rootCmd.AddCommand(project.ProjectCmd)
+	rootCmd.AddCommand(user.UserCmd)
 
 	rootCmd.PersistentFlags().Var(
 		flags.StringChoice(
@@ -40,6 +84,17 @@ func init() {
 		"style",
 		"output table style [default, md]",
 	)
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "do not show the progress of fetches")
+	rootCmd.PersistentFlags().Duration(
+		"timeout",
+		0,
+		"stop fetching after the given duration, e.g. 30s, showing partial results",
+	)
+	rootCmd.PersistentFlags().StringArray(
+		"set",
+		[]string{},
+		"override a configuration value for this run, as <key>=<value>",
+	)
 }
 
 var rootCmd = &cobra.Command{
@@ -48,7 +103,18 @@ var rootCmd = &cobra.Command{
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		if cmd.CalledAs() == configure.ConfigCmd.Use {
+		// Timeout Flag
+		if timeout, err := cmd.Flags().GetDuration("timeout"); err != nil {
+			return err
+		} else if timeout > 0 {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			cmd.SetContext(ctx)
+			cancelTimeout = cancel
+		}
+
+		if cmd.CalledAs() == configure.ConfigCmd.Use ||
+			cmd == configure.ImportCmd ||
+			cmd == configure.EditCmd {
 			return nil
 		}
 
@@ -59,6 +125,31 @@ var rootCmd = &cobra.Command{
 		if !ok {
 			return fmt.Errorf("configuration not found, run the 'configure' command to generate it")
 		}
+
+		// Set Flag
+		if values, err := cmd.Flags().GetStringArray("set"); err != nil {
+			return err
+		} else {
+			for _, value := range values {
+				key, value, ok := strings.Cut(value, "=")
+				if !ok {
+					return fmt.Errorf("invalid override '%s', must be <key>=<value>", key)
+				}
+				if err := config.Override(key, value); err != nil {
+					return err
+				}
+			}
+		}
+
+		notion.SetConcurrency(config.MaxWorkers(), config.RequestsPerSecond())
+
+		// Refresh after overrides, which may change the maximum age or
+		// the databases, and limits are set
+		if cmd != configure.RefreshCmd && cmd != configure.ExportCmd {
+			if err := config.RefreshIfStale(cmd.Context()); err != nil {
+				ui.PrintlnfWarn("Could not refresh users and projects: %s", err)
+			}
+		}
 		return nil
 	},
 }
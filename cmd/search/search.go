@@ -0,0 +1,283 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+)
+
+// Table column names
+var (
+	keyStoryId = "storyId"
+	keyProject = "project"
+	keyStatus  = "status"
+	keySnippet = "snippet"
+)
+
+// Characters of context shown around the first match in a snippet
+const snippetContext = 30
+
+type searchResult struct {
+	Task    notion.Task
+	Score   int
+	Snippet string
+}
+
+func init() {
+	SearchCmd.Flags().Bool("content", false, "search the page content of tasks as well as their names")
+
+	// Limits
+	SearchCmd.Flags().Bool("all", false, "show all the results")
+	SearchCmd.Flags().IntP("limit", "l", 50, "limit the number of results shown")
+	SearchCmd.MarkFlagsMutuallyExclusive("all", "limit")
+}
+
+var SearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "search tasks by name and content",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Load config
+		projectsMap := config.ProjectsMap()
+
+		fields := strings.Fields(strings.Join(args, " "))
+		if len(fields) == 0 {
+			return fmt.Errorf("empty search query")
+		}
+		query := strings.Join(fields, " ")
+		terms := make([]string, 0, len(fields))
+		for _, field := range fields {
+			if !slices.ContainsFunc(terms, func(t string) bool { return strings.EqualFold(t, field) }) {
+				terms = append(terms, field)
+			}
+		}
+		quoted := make([]string, 0, len(terms))
+		for _, term := range terms {
+			quoted = append(quoted, regexp.QuoteMeta(term))
+		}
+		termsRegexp := regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+
+		// Content Flag
+		content, err := cmd.Flags().GetBool("content")
+		if err != nil {
+			return err
+		}
+
+		// Names can only be filtered server side when content is ignored
+		filter := notion.TaskFilter{}
+		if !content {
+			filter.Names = terms
+		}
+
+		// All / Limit Flag, applied to the ranked results
+		limit := -1
+		if all, err := cmd.Flags().GetBool("all"); err != nil {
+			return fmt.Errorf("failed request: %s", err)
+		} else if !all {
+			if limit, err = cmd.Flags().GetInt("limit"); err != nil {
+				return err
+			}
+		}
+
+		// Fetch
+		taskFetcher := notionClient.NewTaskFetcher(
+			ctx,
+			config.TasksDatabaseID(),
+			filter,
+		)
+		tasks, err := taskFetcher.All()
+		if err != nil {
+			return err
+		}
+
+		// Fetch contents, including nested blocks
+		contents := make([][]notion.BlockNode, len(tasks))
+		if content {
+			group, groupCtx := notionClient.NewGroup(ctx)
+			for i, task := range tasks {
+				group.Go(func() error {
+					nodes, err := notionClient.FetchBlockTree(groupCtx, task.ID)
+					contents[i] = nodes
+					return err
+				})
+			}
+			if err := group.Wait(); err != nil {
+				return err
+			}
+		}
+
+		// Score tasks
+		results := make([]searchResult, 0)
+		for i, task := range tasks {
+			matched := make(map[string]bool, len(terms))
+			score := scoreText(task.Name, query, terms, matched) * 3
+			snippet := ""
+			if score > 0 {
+				snippet = task.Name
+			}
+
+			if content {
+				score += scoreBlocks(contents[i], query, terms, matched, &snippet)
+			}
+
+			if score == 0 || len(matched) < len(terms) {
+				continue
+			}
+			results = append(results, searchResult{
+				Task:    task,
+				Score:   score,
+				Snippet: snippet,
+			})
+		}
+
+		// Rank results
+		slices.SortStableFunc(results, func(a, b searchResult) int {
+			if a.Score != b.Score {
+				return b.Score - a.Score
+			}
+			return b.Task.StoryID - a.Task.StoryID
+		})
+		found := len(results)
+		if limit >= 0 && len(results) > limit {
+			results = results[:limit]
+		}
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		var highlight func(string) string
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+				highlight = func(s string) string { return "**" + s + "**" }
+			default:
+				tableStyle = etable.TableStyleDefault
+				highlight = func(s string) string { return ui.HighlightStyle.Render(s) }
+			}
+		}
+
+		columns := []etable.TableColumn{
+			etable.NewTableColumn(keyStoryId, "Story ID"),
+			etable.NewTableColumn(keyProject, "Project"),
+			etable.NewTableColumn(keyStatus, "Status").WithValueFunc(
+				func(value string) string {
+					if config.UseEmotes() {
+						emote := config.StatusEmote(value)
+						if emote != "" {
+							value = fmt.Sprintf("%s %s", emote, value)
+						}
+					}
+					return value
+				},
+			),
+			etable.NewTableColumn(keySnippet, "Match"),
+		}
+
+		// Add rows
+		rows := make([]etable.TableRow, 0, len(results))
+		for _, result := range results {
+			project := ""
+			if result.Task.ProjectID != nil {
+				project = projectsMap[*result.Task.ProjectID]
+			}
+			rows = append(rows, etable.TableRow{
+				keyStoryId: fmt.Sprintf("STORY-%d", result.Task.StoryID),
+				keyProject: project,
+				keyStatus:  result.Task.Status,
+				keySnippet: termsRegexp.ReplaceAllStringFunc(
+					snippetAround(result.Snippet, termsRegexp),
+					highlight,
+				),
+			})
+		}
+
+		// Render result
+		table := etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows)
+		fmt.Println()
+		fmt.Println(table.Render())
+
+		resultLog := fmt.Sprintf("\nFound %d tasks out of %d searched", found, len(tasks))
+		if found > len(rows) {
+			resultLog += fmt.Sprintf(", showing the first %d", len(rows))
+		}
+		ui.PrintlnInfo(resultLog)
+
+		return nil
+	},
+}
+
+// scoreText scores how well text matches the query, an occurrence of the
+// whole query weights more than the occurrence of single terms. Matched terms
+// are recorded in matched.
+func scoreText(text string, query string, terms []string, matched map[string]bool) int {
+	text = strings.ToLower(text)
+	score := 0
+	if len(terms) > 1 {
+		score += 5 * strings.Count(text, strings.ToLower(query))
+	}
+	for _, term := range terms {
+		if n := strings.Count(text, strings.ToLower(term)); n > 0 {
+			matched[term] = true
+			score += n
+		}
+	}
+	return score
+}
+
+// scoreBlocks scores the text of blocks and of their children, setting
+// snippet to the first matching text when it is still empty.
+func scoreBlocks(nodes []notion.BlockNode, query string, terms []string, matched map[string]bool, snippet *string) int {
+	score := 0
+	for _, node := range nodes {
+		if text := notion.ParseBlockText(node.Block); text != "" {
+			s := scoreText(text, query, terms, matched)
+			if s > 0 && *snippet == "" {
+				*snippet = text
+			}
+			score += s
+		}
+		score += scoreBlocks(node.Children, query, terms, matched, snippet)
+	}
+	return score
+}
+
+// snippetAround cuts text around the first match of re.
+func snippetAround(text string, re *regexp.Regexp) string {
+	text = strings.Join(strings.Fields(text), " ")
+	loc := re.FindStringIndex(text)
+	if loc == nil {
+		return text
+	}
+
+	start := max(0, loc[0]-snippetContext)
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	end := min(len(text), loc[1]+2*snippetContext)
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	snippet := text[start:end]
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(text) {
+		snippet += "..."
+	}
+	return snippet
+}
@@ -0,0 +1,253 @@
+package sprint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/cmd/task"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/flags"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+	"github.com/ravvio/noty/utils"
+)
+
+// Table column names
+var (
+	keyUser      = "user"
+	keyDays      = "days"
+	keyCapacity  = "capacity"
+	keyAllocated = "allocated"
+	keyRemaining = "remaining"
+	keyLoad      = "load"
+	keyState     = "state"
+)
+
+// Allocation states
+const (
+	stateOver  = "over"
+	stateUnder = "under"
+	stateOk    = "ok"
+)
+
+var planColumns = []etable.TableColumn{
+	etable.NewTableColumn(keyUser, "User"),
+	etable.NewTableColumn(keyDays, "Days").WithAlignment(etable.TableAlignmentRight),
+	etable.NewTableColumn(keyCapacity, "Capacity").WithAlignment(etable.TableAlignmentRight),
+	etable.NewTableColumn(keyAllocated, "Allocated").WithAlignment(etable.TableAlignmentRight),
+	etable.NewTableColumn(keyRemaining, "Remaining").WithAlignment(etable.TableAlignmentRight),
+	etable.NewTableColumn(keyLoad, "Load").WithAlignment(etable.TableAlignmentRight),
+	etable.NewTableColumn(keyState, "State").WithStyleFunc(
+		func(style lipgloss.Style, value string) lipgloss.Style {
+			switch value {
+			case stateOver:
+				return style.Foreground(ui.Error)
+			case stateUnder:
+				return style.Foreground(ui.Accent)
+			case stateOk:
+				return style.Foreground(ui.Success)
+			}
+			return style
+		},
+	),
+}
+
+func init() {
+	PlanCmd.Flags().Var(
+		flags.StringChoiceOrInt([]string{"current", "next"}, "next"),
+		"sprint",
+		"sprint to plan [current, next, <ID>]",
+	)
+	PlanCmd.Flags().Float64("under", 0.8, "load below which a user is considered under-allocated")
+}
+
+var PlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "compare the estimates assigned in a sprint with users capacity",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Load config
+		dateFormat := config.DateFormat()
+		holidays, err := config.Holidays()
+		if err != nil {
+			return err
+		}
+
+		// Flags
+		under, err := cmd.Flags().GetFloat64("under")
+		if err != nil {
+			return err
+		}
+		sprintFlag, err := cmd.Flags().GetString("sprint")
+		if err != nil {
+			return err
+		}
+
+		// Fetch sprint
+		sprint, err := task.FetchSprint(ctx, notionClient, sprintFlag)
+		if err != nil {
+			return err
+		}
+		if sprint.Dates.Start.IsZero() {
+			return fmt.Errorf("sprint '%s' has no dates", sprint.Name)
+		}
+
+		// Fetch tasks
+		taskFetcher := notionClient.NewTaskFetcher(
+			ctx,
+			config.TasksDatabaseID(),
+			notion.TaskFilter{
+				Sprint: notion.TaskSprintByID{
+					ID: sprint.ID,
+				},
+			},
+		)
+		tasks, err := taskFetcher.All()
+		if err != nil {
+			return err
+		}
+
+		capacities, err := config.Capacities(ctx)
+		if err != nil {
+			return err
+		}
+		daysOff, err := config.DaysOff(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Allocated estimate per user ID, unassigned tasks under -
+		names := map[string]string{"-": "Unassigned"}
+		for _, user := range config.Users() {
+			names[user.ID] = user.Name
+		}
+		allocated := make(map[string]float64)
+		for _, t := range tasks {
+			if config.HasStatusRole(t.Status, config.RoleNotDone) {
+				continue
+			}
+			id := "-"
+			if len(t.AssigneeIDs) > 0 {
+				id = t.AssigneeIDs[0]
+				if _, ok := names[id]; !ok {
+					names[id] = t.Assignee
+				}
+			}
+			allocated[id] += t.Estimate
+		}
+
+		// Users with tasks or a configured capacity
+		for id := range capacities {
+			if _, ok := allocated[id]; !ok {
+				allocated[id] = 0
+			}
+		}
+		users := utils.MapKeys(allocated)
+		slices.SortFunc(users, func(a, b string) int {
+			return strings.Compare(names[a], names[b])
+		})
+
+		// Add rows
+		workingDays := WorkingDays(sprint.Dates.Start, sprint.Dates.End, holidays)
+		rows := make([]etable.TableRow, 0, len(users))
+		for _, user := range users {
+			hours := allocated[user]
+
+			// Unassigned tasks have no capacity
+			if user == "-" {
+				rows = append(rows, etable.TableRow{
+					keyUser:      names[user],
+					keyAllocated: fmt.Sprintf("%.1f h", hours),
+				})
+				continue
+			}
+
+			days := len(WorkingDays(sprint.Dates.Start, sprint.Dates.End, append(daysOff[user], holidays...)))
+			hoursPerDay, ok := capacities[user]
+			if !ok {
+				hoursPerDay = config.HoursPerDay()
+			}
+			capacity := float64(days) * hoursPerDay
+
+			state := stateOk
+			load := ""
+			if capacity > 0 {
+				ratio := hours / capacity
+				load = fmt.Sprintf("%.0f%%", ratio*100)
+				if ratio > 1 {
+					state = stateOver
+				} else if ratio < under {
+					state = stateUnder
+				}
+			} else if hours > 0 {
+				state = stateOver
+			}
+
+			rows = append(rows, etable.TableRow{
+				keyUser:      names[user],
+				keyDays:      fmt.Sprintf("%d", days),
+				keyCapacity:  fmt.Sprintf("%.1f h", capacity),
+				keyAllocated: fmt.Sprintf("%.1f h", hours),
+				keyRemaining: fmt.Sprintf("%.1f h", capacity-hours),
+				keyLoad:      load,
+				keyState:     state,
+			})
+		}
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		// Render result
+		fmt.Printf(
+			"\n%s (%s - %s, %d working days)\n\n",
+			sprint.Name,
+			sprint.Dates.Start.Format(dateFormat),
+			sprint.Dates.End.Format(dateFormat),
+			len(workingDays),
+		)
+		fmt.Println(etable.NewTable(planColumns).WithStyle(tableStyle).WithRows(rows).Render())
+		ui.PrintlnfInfo("\nPlanned %d tasks", len(tasks))
+
+		return nil
+	},
+}
+
+// WorkingDays returns the days between start and end, both included,
+// skipping weekends and the given days off.
+func WorkingDays(start time.Time, end time.Time, daysOff []time.Time) []time.Time {
+	excluded := make([]string, 0, len(daysOff))
+	for _, day := range daysOff {
+		excluded = append(excluded, day.Format(time.DateOnly))
+	}
+
+	days := make([]time.Time, 0)
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	last := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	for !day.After(last) {
+		if day.Weekday() != time.Saturday &&
+			day.Weekday() != time.Sunday &&
+			!slices.Contains(excluded, day.Format(time.DateOnly)) {
+			days = append(days, day)
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return days
+}
@@ -0,0 +1,205 @@
+package sprint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/cmd/task"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/flags"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+)
+
+// Table column names
+var (
+	keyStoryId  = "storyId"
+	keyName     = "name"
+	keyAssignee = "assignee"
+	keyStatus   = "status"
+	keyEstimate = "estimate"
+)
+
+var rolloverColumns = []etable.TableColumn{
+	etable.NewTableColumn(keyStoryId, "Story ID"),
+	etable.NewTableColumn(keyName, "Name").WithMaxWidth(40),
+	etable.NewTableColumn(keyAssignee, "Assignee"),
+	etable.NewTableColumn(keyStatus, "Status"),
+	etable.NewTableColumn(keyEstimate, "Estimate").WithAlignment(etable.TableAlignmentRight),
+}
+
+func init() {
+	RolloverCmd.Flags().Var(
+		flags.StringChoiceOrInt([]string{"current", "next"}, "current"),
+		"from",
+		"sprint to move tasks from [current, next, <ID>]",
+	)
+	RolloverCmd.Flags().Var(
+		flags.StringChoiceOrInt([]string{"current", "next"}, "next"),
+		"to",
+		"sprint to move tasks to [current, next, <ID>]",
+	)
+	RolloverCmd.Flags().StringSliceP(
+		"status",
+		"s",
+		[]string{},
+		"status codes of the tasks to move, defaults to all open statuses",
+	)
+	RolloverCmd.Flags().Bool("dry-run", false, "show the tasks that would be moved without updating them")
+}
+
+var RolloverCmd = &cobra.Command{
+	Use:   "rollover",
+	Short: "move unfinished tasks from a sprint to another",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Flags
+		fromFlag, err := cmd.Flags().GetString("from")
+		if err != nil {
+			return err
+		}
+		toFlag, err := cmd.Flags().GetString("to")
+		if err != nil {
+			return err
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+
+		// Status Flag
+		statusFlag, err := cmd.Flags().GetStringSlice("status")
+		if err != nil {
+			return err
+		}
+		statuses, err := config.ParseStatusCodes(statusFlag)
+		if err != nil {
+			return err
+		}
+		if len(statuses) == 0 {
+			statuses = config.OpenStatuses()
+		}
+		for _, status := range statuses {
+			if !config.IsOpenStatus(status) {
+				return fmt.Errorf("cannot roll over tasks with closed status '%s'", status)
+			}
+		}
+
+		// Fetch sprints
+		var from, to *notion.Sprint
+		group, groupCtx := notionClient.NewGroup(ctx)
+		group.Go(func() error {
+			var err error
+			from, err = task.FetchSprint(groupCtx, notionClient, fromFlag)
+			return err
+		})
+		group.Go(func() error {
+			var err error
+			to, err = task.FetchSprint(groupCtx, notionClient, toFlag)
+			return err
+		})
+		if err := group.Wait(); err != nil {
+			return err
+		}
+		if from.ID == to.ID {
+			return fmt.Errorf("source and target sprint are both '%s'", from.Name)
+		}
+
+		// Fetch tasks
+		taskFetcher := notionClient.NewTaskFetcher(
+			ctx,
+			config.TasksDatabaseID(),
+			notion.TaskFilter{
+				Statuses: statuses,
+				Sprint: notion.TaskSprintByID{
+					ID: from.ID,
+				},
+			},
+		)
+		tasks, err := taskFetcher.All()
+		if err != nil {
+			return err
+		}
+
+		// Add rows
+		moved := 0
+		already := 0
+		failed := make([]string, 0)
+		hours := 0.0
+		rows := make([]etable.TableRow, 0, len(tasks))
+		for _, t := range tasks {
+			// Tasks moved by a previous run are not moved again
+			if slices.Contains(t.SprintIDs, to.ID) {
+				already++
+				continue
+			}
+			if !dryRun {
+				// Previous sprints are kept to preserve the task history
+				sprintIDs := append(slices.Clone(t.SprintIDs), to.ID)
+				if err := notionClient.SetTaskSprints(ctx, t.ID, sprintIDs); err != nil {
+					ui.PrintlnfWarn("Could not move STORY-%d: %s", t.StoryID, err)
+					failed = append(failed, fmt.Sprintf("STORY-%d", t.StoryID))
+					continue
+				}
+			}
+			moved++
+			hours += t.Estimate
+
+			rows = append(rows, etable.TableRow{
+				keyStoryId:  fmt.Sprintf("STORY-%d", t.StoryID),
+				keyName:     t.Name,
+				keyAssignee: t.Assignee,
+				keyStatus:   t.Status,
+				keyEstimate: fmt.Sprintf("%.1f h", t.Estimate),
+			})
+		}
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		// Render result
+		fmt.Println()
+		fmt.Println(etable.NewTable(rolloverColumns).WithStyle(tableStyle).WithRows(rows).Render())
+		if dryRun {
+			ui.PrintlnfInfo(
+				"\n%d tasks (%.1f h) would be moved from %s to %s",
+				moved,
+				hours,
+				from.Name,
+				to.Name,
+			)
+		} else {
+			ui.PrintlnfSuccess(
+				"\nMoved %d tasks (%.1f h) from %s to %s",
+				moved,
+				hours,
+				from.Name,
+				to.Name,
+			)
+		}
+		if already > 0 {
+			ui.PrintlnfInfo("%d tasks are already in %s", already, to.Name)
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("could not move %d tasks: %s", len(failed), strings.Join(failed, ", "))
+		}
+
+		return nil
+	},
+}
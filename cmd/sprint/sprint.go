@@ -0,0 +1,15 @@
+package sprint
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	SprintCmd.AddCommand(PlanCmd)
+	SprintCmd.AddCommand(RolloverCmd)
+}
+
+var SprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "plan and manage sprints",
+}
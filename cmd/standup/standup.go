@@ -0,0 +1,260 @@
+package standup
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/flags"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/utils"
+)
+
+// standupData holds what is fetched to build the report of a user.
+type standupData struct {
+	OpenTasks    []notion.Task
+	ClosedTasks  []notion.Task
+	HoursEntries []notion.HoursEntry
+}
+
+func init() {
+	StandupCmd.Flags().StringSliceP("users", "u", []string{"me"}, "users to generate the report for")
+	StandupCmd.Flags().VarP(
+		flags.StringChoice([]string{"md", "text"}, "md"),
+		"format",
+		"f",
+		"output format [md, text]",
+	)
+}
+
+var StandupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "generate a daily standup report",
+	Long: `Generate a daily standup report with, for each user, the tasks done since
+the last working day, the tasks in progress and to review and the hours
+logged on the last working day.
+
+Done tasks are the ones in review or done and last edited since the last
+working day. The tasks database has no date of status changes, so a later
+edit of an older task, e.g. a new comment, lists it again.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Load config
+		projectsMap := config.ProjectsMap()
+		dateFormat := config.DateFormat()
+
+		// Users Flag
+		usernames, err := cmd.Flags().GetStringSlice("users")
+		if err != nil {
+			return err
+		}
+		users, err := config.ParseUsers(ctx, usernames)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return fmt.Errorf("no user to generate the report for")
+		}
+
+		// Format Flag
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		report := newReport(format)
+
+		lastWorkingDay := LastWorkingDay(time.Now())
+
+		// Statuses of tasks being worked on or reviewed, and of the ones
+		// completed by their assignee
+		openStatuses := append(
+			config.StatusesWithRole(config.RoleInProgress),
+			config.StatusesWithRole(config.RoleReview)...,
+		)
+		closedStatuses := append(
+			config.StatusesWithRole(config.RoleReview),
+			config.StatusesWithRole(config.RoleDone)...,
+		)
+		if len(openStatuses) == 0 || len(closedStatuses) == 0 {
+			return fmt.Errorf("no status has the %s, %s or %s role, see the statuses configuration", config.RoleInProgress, config.RoleReview, config.RoleDone)
+		}
+
+		// Fetch the data of all users
+		data := make([]standupData, len(users))
+		group, groupCtx := notionClient.NewGroup(ctx)
+		for i, user := range users {
+			// Open tasks
+			notion.FetchAll(
+				group,
+				notionClient.NewTaskFetcher(
+					groupCtx,
+					config.TasksDatabaseID(),
+					notion.TaskFilter{
+						Users:    []string{user.ID},
+						Statuses: openStatuses,
+					},
+				),
+				&data[i].OpenTasks,
+			)
+
+			// Tasks closed since the last working day, approximated by
+			// the time of their last edit
+			notion.FetchAll(
+				group,
+				notionClient.NewTaskFetcher(
+					groupCtx,
+					config.TasksDatabaseID(),
+					notion.TaskFilter{
+						Assignees:   []string{user.ID},
+						Statuses:    closedStatuses,
+						EditedAfter: &lastWorkingDay,
+					},
+				),
+				&data[i].ClosedTasks,
+			)
+
+			// Hours of the last working day
+			notion.FetchAll(
+				group,
+				notionClient.NewHoursFetcher(
+					groupCtx,
+					config.HoursDatabaseID(),
+					notion.HoursFilter{
+						Users: []string{user.ID},
+						Date: notion.HoursDateExact{
+							Date: time.Date(
+								lastWorkingDay.Year(),
+								lastWorkingDay.Month(),
+								lastWorkingDay.Day(),
+								0, 0, 0, 0,
+								time.UTC,
+							),
+						},
+					},
+				),
+				&data[i].HoursEntries,
+			)
+		}
+		if err := group.Wait(); err != nil {
+			return err
+		}
+
+		for i, user := range users {
+			openTasks := data[i].OpenTasks
+			closedTasks := data[i].ClosedTasks
+			hoursEntries := data[i].HoursEntries
+
+			// Build sections
+			report.Title(user.Name)
+
+			done := make([]string, 0)
+			for _, task := range closedTasks {
+				done = append(done, taskLine(task))
+			}
+			report.List(
+				fmt.Sprintf("Done since %s", lastWorkingDay.Format(dateFormat)),
+				done,
+			)
+
+			inProgress := make([]string, 0)
+			review := make([]string, 0)
+			for _, task := range openTasks {
+				if config.HasStatusRole(task.Status, config.RoleInProgress) && task.IsAssignee(user.ID) {
+					inProgress = append(inProgress, taskLine(task))
+				}
+				if config.HasStatusRole(task.Status, config.RoleReview) && task.IsReviewer(user.ID) {
+					review = append(review, taskLine(task))
+				}
+			}
+			report.List("In progress", inProgress)
+			report.List("To review", review)
+
+			total := 0.0
+			projectHours := make(map[string]float64)
+			for _, entry := range hoursEntries {
+				project := "-"
+				if entry.ProjectID != nil {
+					project = projectsMap[*entry.ProjectID]
+				}
+				projectHours[project] += entry.Hours
+				total += entry.Hours
+			}
+			projects := utils.MapKeys(projectHours)
+			slices.Sort(projects)
+			hours := make([]string, 0, len(projects))
+			for _, project := range projects {
+				hours = append(hours, fmt.Sprintf("%s: %.1f h", project, projectHours[project]))
+			}
+			report.List(
+				fmt.Sprintf("Hours on %s (%.1f h)", lastWorkingDay.Format(dateFormat), total),
+				hours,
+			)
+		}
+
+		fmt.Print(report.String())
+		return nil
+	},
+}
+
+// LastWorkingDay returns the start of the last working day before t,
+// skipping weekends.
+func LastWorkingDay(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for {
+		day = day.AddDate(0, 0, -1)
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			return day
+		}
+	}
+}
+
+func taskLine(task notion.Task) string {
+	return fmt.Sprintf("STORY-%d %s (%s)", task.StoryID, task.Name, task.Status)
+}
+
+// report builds a standup report either as markdown or as plain text.
+type report struct {
+	markdown bool
+	sb       strings.Builder
+}
+
+func newReport(format string) *report {
+	return &report{
+		markdown: format == "md",
+	}
+}
+
+func (r *report) Title(title string) {
+	if r.sb.Len() > 0 {
+		r.sb.WriteString("\n")
+	}
+	if r.markdown {
+		fmt.Fprintf(&r.sb, "## %s\n", title)
+	} else {
+		fmt.Fprintf(&r.sb, "%s\n%s\n", title, strings.Repeat("=", len(title)))
+	}
+}
+
+func (r *report) List(title string, items []string) {
+	if r.markdown {
+		fmt.Fprintf(&r.sb, "\n**%s**\n", title)
+	} else {
+		fmt.Fprintf(&r.sb, "\n%s:\n", title)
+	}
+	if len(items) == 0 {
+		r.sb.WriteString("- none\n")
+	}
+	for _, item := range items {
+		fmt.Fprintf(&r.sb, "- %s\n", item)
+	}
+}
+
+func (r *report) String() string {
+	return r.sb.String()
+}
@@ -0,0 +1,115 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+)
+
+// Comments table column names
+var (
+	keyAuthor  = "author"
+	keyComment = "comment"
+)
+
+var CommentsCmd = &cobra.Command{
+	Use:   "comments <story-id>",
+	Short: "list the comments of a task",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Load config
+		usersMap := config.UsersMap()
+		timeFormat := config.DatetimeFormat()
+
+		// Fetch task
+		task, err := fetchTask(ctx, notionClient, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Fetch comments
+		commentFetcher := notionClient.NewCommentFetcher(ctx, task.ID)
+		comments, err := commentFetcher.All()
+		if err != nil {
+			return err
+		}
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		columns := []etable.TableColumn{
+			etable.NewTableColumn(keyCreatedTime, "Created"),
+			etable.NewTableColumn(keyAuthor, "Author"),
+			etable.NewTableColumn(keyComment, "Comment"),
+		}
+
+		// Add rows
+		rows := make([]etable.TableRow, 0, len(comments))
+		for _, comment := range comments {
+			author, ok := usersMap[comment.AuthorID]
+			if !ok {
+				author = "-"
+			}
+			rows = append(rows, etable.TableRow{
+				keyCreatedTime: comment.Created.Local().Format(timeFormat),
+				keyAuthor:      author,
+				keyComment:     strings.TrimSpace(comment.Text),
+			})
+		}
+
+		// Render result
+		fmt.Printf("\nSTORY-%d %s\n\n", task.StoryID, task.Name)
+		fmt.Println(etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows).Render())
+		ui.PrintlnfInfo("\nFetched %d comments", len(rows))
+
+		return nil
+	},
+}
+
+var CommentCmd = &cobra.Command{
+	Use:   "comment <story-id> <message>",
+	Short: "add a comment to a task",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		message := strings.TrimSpace(strings.Join(args[1:], " "))
+		if message == "" {
+			return fmt.Errorf("empty comment")
+		}
+
+		// Fetch task
+		task, err := fetchTask(ctx, notionClient, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Post comment
+		if _, err := notionClient.CreateComment(ctx, task.ID, message); err != nil {
+			return err
+		}
+		ui.PrintlnfSuccess("Comment added to STORY-%d %s", task.StoryID, task.Name)
+
+		return nil
+	},
+}
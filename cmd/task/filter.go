@@ -0,0 +1,166 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/flags"
+	"github.com/ravvio/noty/notion"
+)
+
+// AddFilterFlags adds to cmd the flags used to scope the tasks to fetch,
+// parsed by ParseFilterFlags.
+func AddFilterFlags(cmd *cobra.Command, defaultSprint string) {
+	// Users
+	cmd.Flags().StringSliceP("users", "u", []string{}, "filter tasks by users (assignee or reviewer)")
+	cmd.Flags().StringSliceP("assignees", "a", []string{}, "filter tasks by assignees")
+	cmd.Flags().StringSliceP("reviewers", "r", []string{}, "filter tasks by reviewers")
+	cmd.MarkFlagsMutuallyExclusive("assignees", "users")
+	cmd.MarkFlagsMutuallyExclusive("reviewers", "users")
+
+	// Project
+	cmd.Flags().StringSliceP("project", "p", []string{}, "filter by project(s)")
+
+	// Status
+	cmd.Flags().StringSliceP("status", "s", []string{}, "filter tasks by status code(s), as configured in statuses")
+
+	// Sprint
+	cmd.Flags().Var(
+		flags.StringChoiceOrInt([]string{"default", "all", "backlog", "current", "next"}, defaultSprint),
+		"sprint",
+		"sprint to search tasks in, by default ingnores backlog [all, default, backlog, current, <ID>]",
+	)
+}
+
+// ParseFilterFlags builds a task filter from the flags added by
+// AddFilterFlags. The sprint is left to SprintFilter, so that the lookup it
+// may need can run along with other fetches.
+func ParseFilterFlags(
+	ctx context.Context,
+	cmd *cobra.Command,
+) (notion.TaskFilter, error) {
+	filter := notion.TaskFilter{}
+
+	// Assignee Flag
+	if assignees, err := cmd.Flags().GetStringSlice("assignees"); err != nil {
+		return filter, err
+	} else if len(assignees) != 0 {
+		users, err := config.ParseUsers(ctx, assignees)
+		if err != nil {
+			return filter, err
+		}
+		for _, user := range users {
+			filter.Assignees = append(filter.Assignees, user.ID)
+		}
+	}
+	// Reviewer Flag
+	if reviewers, err := cmd.Flags().GetStringSlice("reviewers"); err != nil {
+		return filter, err
+	} else if len(reviewers) != 0 {
+		users, err := config.ParseUsers(ctx, reviewers)
+		if err != nil {
+			return filter, err
+		}
+		for _, user := range users {
+			filter.Reviewers = append(filter.Reviewers, user.ID)
+		}
+	}
+	// User Flag
+	if usernames, err := cmd.Flags().GetStringSlice("users"); err != nil {
+		return filter, err
+	} else if len(usernames) != 0 {
+		users, err := config.ParseUsers(ctx, usernames)
+		if err != nil {
+			return filter, err
+		}
+		for _, user := range users {
+			filter.Users = append(filter.Users, user.ID)
+		}
+	}
+
+	// Projects Flag
+	if projects, err := cmd.Flags().GetStringSlice("project"); err != nil {
+		return filter, err
+	} else if len(projects) > 0 {
+		projects, err := config.ParseProjects(ctx, projects)
+		if err != nil {
+			return filter, err
+		}
+		for _, project := range projects {
+			filter.Projects = append(filter.Projects, project.ID)
+		}
+	}
+	// Status Flag
+	if statuses, err := cmd.Flags().GetStringSlice("status"); err != nil {
+		return filter, err
+	} else if filter.Statuses, err = config.ParseStatusCodes(statuses); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// SprintFilter builds the sprint filter from the sprint flag added by
+// AddFilterFlags, looking the sprint up when given as current, next or a
+// number.
+func SprintFilter(
+	ctx context.Context,
+	cmd *cobra.Command,
+	notionClient *notion.Client,
+) (notion.TaskSprintFilter, error) {
+	sprint, err := cmd.Flags().GetString("sprint")
+	if err != nil {
+		return nil, err
+	}
+	switch sprint {
+	case "default":
+		return notion.TaskSprintNoBacklog{}, nil
+	case "all":
+		return nil, nil
+	case "backlog":
+		return notion.TaskSprintOnlyBacklog{}, nil
+	}
+
+	res, err := FetchSprint(ctx, notionClient, sprint)
+	if err != nil {
+		return nil, err
+	}
+	return notion.TaskSprintByID{
+		ID: res.ID,
+	}, nil
+}
+
+// FetchSprint fetches a sprint given either 'current', 'next' or its number.
+func FetchSprint(
+	ctx context.Context,
+	notionClient *notion.Client,
+	sprint string,
+) (*notion.Sprint, error) {
+	filter := notion.SprintFilter{}
+	if sprint == "current" || sprint == "next" {
+		var s string
+		switch sprint {
+		case "current":
+			s = "Current"
+		case "next":
+			s = "Next"
+		}
+		filter.Status = &s
+	} else if sprintId, err := strconv.Atoi(sprint); err == nil {
+		id := sprintId + 1
+		filter.ID = &id
+	} else {
+		return nil, fmt.Errorf("invalid sprint '%s', must be current, next or a sprint number", sprint)
+	}
+
+	sprintFetcher := notionClient.NewSprintFetcher(
+		ctx,
+		config.SprintsDatabaseID(),
+		filter,
+	)
+	return sprintFetcher.NextOne()
+}
@@ -0,0 +1,255 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/export"
+	"github.com/ravvio/noty/grouping"
+	"github.com/ravvio/noty/notion"
+)
+
+// Dimensions tasks can be grouped by
+var GroupChoices = []string{"assignee", "project", "status", "priority", "reviewer", "sprint", "created-week"}
+
+// Values shown in the cells of pivot tables
+var pivotValueChoices = []string{"count", "estimate"}
+
+// Priorities from the highest
+var PriorityOrder = []string{"High", "Medium", "Low"}
+
+// Grouping column names
+var (
+	keyMin         = "min"
+	keyMax         = "max"
+	keyAvg         = "avg"
+	keyCreatedWeek = "createdWeek"
+)
+
+// Prefix of the keys of the columns counting tasks per status
+const statusKeyPrefix = "status:"
+
+// TaskDimensions returns the dimensions tasks can be grouped by, keyed by
+// their name in the group-by flag. sprintName names the sprints of a task.
+func TaskDimensions(
+	projectsMap map[string]string,
+	sprintName func(ids []string) string,
+) map[string]grouping.Dimension[notion.Task] {
+	statuses := make([]string, 0)
+	for _, status := range config.Statuses() {
+		statuses = append(statuses, status.Name)
+	}
+
+	return map[string]grouping.Dimension[notion.Task]{
+		"assignee": {
+			Key:   keyAssignee,
+			Title: "Assignee",
+			Value: func(task notion.Task) string { return task.Assignee },
+		},
+		"project": {
+			Key:   keyProject,
+			Title: "Project",
+			Value: func(task notion.Task) string {
+				if task.ProjectID != nil {
+					return projectsMap[*task.ProjectID]
+				}
+				return ""
+			},
+		},
+		"status": {
+			Key:     keyStatus,
+			Title:   "Status",
+			Value:   func(task notion.Task) string { return task.Status },
+			Compare: grouping.CompareOrder(statuses),
+		},
+		"priority": {
+			Key:     keyPriority,
+			Title:   "Priority",
+			Value:   func(task notion.Task) string { return task.Priority },
+			Compare: grouping.CompareOrder(PriorityOrder),
+		},
+		"reviewer": {
+			Key:   keyReviewer,
+			Title: "Reviewer",
+			Value: func(task notion.Task) string { return task.Reviewer },
+		},
+		"sprint": {
+			Key:   keySprint,
+			Title: "Sprint",
+			Value: func(task notion.Task) string { return sprintName(task.SprintIDs) },
+		},
+		"created-week": {
+			Key:   keyCreatedWeek,
+			Title: "Created Week",
+			Value: func(task notion.Task) string {
+				year, week := task.Created.Local().ISOWeek()
+				return fmt.Sprintf("%d-W%02d", year, week)
+			},
+		},
+	}
+}
+
+// ParseGrouping returns the dimensions named in the group-by flag.
+func ParseGrouping(
+	names []string,
+	dimensions map[string]grouping.Dimension[notion.Task],
+) ([]grouping.Dimension[notion.Task], error) {
+	dims := make([]grouping.Dimension[notion.Task], 0, len(names))
+	for i, name := range names {
+		if slices.Contains(names[:i], name) {
+			return nil, fmt.Errorf("cannot group by '%s' more than once", name)
+		}
+		dim, ok := dimensions[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown grouping '%s', valid values are %v", name, GroupChoices)
+		}
+		dims = append(dims, dim)
+	}
+	return dims, nil
+}
+
+// ParsePivot returns the dimensions of the rows and of the columns of a
+// pivot flag given as <rows>:<columns>.
+func ParsePivot(
+	value string,
+	dimensions map[string]grouping.Dimension[notion.Task],
+) ([]grouping.Dimension[notion.Task], error) {
+	rows, cols, ok := strings.Cut(value, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid pivot '%s', must be <rows>:<columns>, e.g. assignee:status", value)
+	}
+	if rows == cols {
+		return nil, fmt.Errorf("invalid pivot '%s', rows and columns must differ", value)
+	}
+	return ParseGrouping([]string{rows, cols}, dimensions)
+}
+
+// PivotTable returns the columns and rows of a pivot table of tasks, counting
+// them or summing their estimates.
+func PivotTable(
+	tasks []notion.Task,
+	rows grouping.Dimension[notion.Task],
+	cols grouping.Dimension[notion.Task],
+	value string,
+) ([]etable.TableColumn, []etable.TableRow) {
+	return grouping.Pivot(tasks, rows, cols, func(tasks []notion.Task) string {
+		if value == "estimate" {
+			estimate := 0.0
+			for _, task := range tasks {
+				estimate += task.Estimate
+			}
+			return fmt.Sprintf("%.1f h", estimate)
+		}
+		return fmt.Sprintf("%d", len(tasks))
+	})
+}
+
+// GroupingTable returns the columns and rows of tasks grouped by dims, with
+// estimate statistics and the count of tasks per status.
+func GroupingTable(
+	tasks []notion.Task,
+	dims []grouping.Dimension[notion.Task],
+) ([]etable.TableColumn, []etable.TableRow) {
+	// Statuses of the tasks, in the configured order
+	statuses := make([]string, 0)
+	for _, status := range config.Statuses() {
+		if slices.ContainsFunc(tasks, func(t notion.Task) bool { return t.Status == status.Name }) {
+			statuses = append(statuses, status.Name)
+		}
+	}
+	for _, task := range tasks {
+		if !slices.Contains(statuses, task.Status) {
+			statuses = append(statuses, task.Status)
+		}
+	}
+
+	aggregates := []etable.TableColumn{
+		etable.NewTableColumn(keyCount, "Count").WithAlignment(etable.TableAlignmentRight),
+		etable.NewTableColumn(keyEstimate, "Estimate").WithAlignment(etable.TableAlignmentRight),
+		etable.NewTableColumn(keyMin, "Min").WithAlignment(etable.TableAlignmentRight),
+		etable.NewTableColumn(keyMax, "Max").WithAlignment(etable.TableAlignmentRight),
+		etable.NewTableColumn(keyAvg, "Avg").WithAlignment(etable.TableAlignmentRight),
+	}
+	for _, status := range statuses {
+		aggregates = append(
+			aggregates,
+			etable.NewTableColumn(statusKeyPrefix+status, status).WithAlignment(etable.TableAlignmentRight),
+		)
+	}
+
+	aggregate := func(tasks []notion.Task) etable.TableRow {
+		counts := make(map[string]int)
+		for _, task := range tasks {
+			counts[task.Status]++
+		}
+		stats := EstimateStats(tasks)
+
+		row := etable.TableRow{
+			keyCount:    fmt.Sprintf("%d", stats.Count),
+			keyEstimate: fmt.Sprintf("%.1f h", stats.Sum),
+			keyMin:      fmt.Sprintf("%.1f h", stats.Min),
+			keyMax:      fmt.Sprintf("%.1f h", stats.Max),
+			keyAvg:      fmt.Sprintf("%.1f h", stats.Avg),
+		}
+		for _, status := range statuses {
+			row[statusKeyPrefix+status] = fmt.Sprintf("%d", counts[status])
+		}
+		return row
+	}
+
+	root := grouping.By(tasks, dims)
+	return grouping.Columns(dims, aggregates...), grouping.Rows(root, dims, aggregate)
+}
+
+// EstimateStats returns the count of tasks and the statistics of their
+// estimates.
+func EstimateStats(tasks []notion.Task) grouping.Stats {
+	estimates := make([]float64, 0, len(tasks))
+	for _, task := range tasks {
+		estimates = append(estimates, task.Estimate)
+	}
+	return grouping.NewStats(estimates)
+}
+
+// groupingExport returns the grouping table of dims to export, with the
+// estimates typed as hours and the counts as integers.
+func groupingExport(
+	columns []etable.TableColumn,
+	rows []etable.TableRow,
+	dims []grouping.Dimension[notion.Task],
+) export.Table {
+	kinds := map[string]export.Kind{
+		"Estimate": export.KindHours,
+		"Min":      export.KindHours,
+		"Max":      export.KindHours,
+		"Avg":      export.KindHours,
+	}
+	for _, dim := range dims {
+		kinds[dim.Title] = export.KindString
+	}
+	return export.Table{Name: "Grouped", Columns: columns, Rows: rows, Kinds: kinds, Others: export.KindInt}
+}
+
+// pivotExport returns the pivot table with rows of dim to export, with the cells
+// typed by value.
+func pivotExport(
+	columns []etable.TableColumn,
+	rows []etable.TableRow,
+	dim grouping.Dimension[notion.Task],
+	value string,
+) export.Table {
+	others := export.KindInt
+	if value == "estimate" {
+		others = export.KindHours
+	}
+	return export.Table{
+		Name:    "Pivot",
+		Columns: columns,
+		Rows:    rows,
+		Kinds:   map[string]export.Kind{dim.Title: export.KindString},
+		Others:  others,
+	}
+}
@@ -0,0 +1,132 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/jomei/notionapi"
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/utils"
+)
+
+func init() {
+	ShowCmd.Flags().Bool("raw", false, "print plain markdown instead of rendering it")
+}
+
+var ShowCmd = &cobra.Command{
+	Use:   "show <story-id>",
+	Short: "show the properties and content of a task",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Load config
+		projectsMap := config.ProjectsMap()
+		timeFormat := config.DatetimeFormat()
+
+		// Fetch task
+		task, err := fetchTask(ctx, notionClient, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Fetch content
+		blocks, err := notionClient.FetchBlockTree(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+
+		// Build document
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "# STORY-%d %s\n\n", task.StoryID, task.Name)
+
+		sb.WriteString("| Property | Value |\n| --- | --- |\n")
+		names := utils.MapKeys(task.Properties)
+		slices.Sort(names)
+		for _, name := range names {
+			property := task.Properties[name]
+			value := notion.ParsePropertyValue(property, timeFormat)
+			if _, ok := property.(*notionapi.RelationProperty); ok {
+				// Replace known project IDs with their names
+				ids := notion.ParseRelation(property)
+				for i, id := range ids {
+					if project, ok := projectsMap[id]; ok {
+						ids[i] = project
+					}
+				}
+				value = strings.Join(ids, ", ")
+			}
+			fmt.Fprintf(&sb, "| %s | %s |\n", name, strings.ReplaceAll(value, "|", "\\|"))
+		}
+		fmt.Fprintf(&sb, "| URL | %s |\n", task.URL)
+
+		if content := notion.BlocksToMarkdown(blocks); content != "" {
+			sb.WriteString("\n---\n\n")
+			sb.WriteString(content)
+		}
+
+		// Render result
+		if raw, err := cmd.Flags().GetBool("raw"); err != nil {
+			return err
+		} else if raw {
+			fmt.Print(sb.String())
+			return nil
+		}
+
+		renderer, err := glamour.NewTermRenderer(
+			glamour.WithAutoStyle(),
+			glamour.WithWordWrap(100),
+		)
+		if err != nil {
+			return err
+		}
+		out, err := renderer.Render(sb.String())
+		if err != nil {
+			return err
+		}
+		fmt.Print(out)
+
+		return nil
+	},
+}
+
+// ParseStoryID parses a story ID both in the 'STORY-123' and '123' forms.
+func ParseStoryID(s string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(s), "STORY-"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid story ID '%s', expected STORY-<number>", s)
+	}
+	return id, nil
+}
+
+// fetchTask fetches a single task given its story ID.
+func fetchTask(ctx context.Context, notionClient *notion.Client, s string) (*notion.Task, error) {
+	storyID, err := ParseStoryID(s)
+	if err != nil {
+		return nil, err
+	}
+
+	taskFetcher := notionClient.NewTaskFetcher(
+		ctx,
+		config.TasksDatabaseID(),
+		notion.TaskFilter{
+			StoryID: &storyID,
+		},
+	).WithLimit(1)
+	tasks, err := taskFetcher.All()
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no task found for 'STORY-%d'", storyID)
+	}
+	return &tasks[0], nil
+}
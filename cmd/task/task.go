@@ -1,29 +1,25 @@
 package task
 
 import (
-	"context"
 	"fmt"
-	"os"
 	"path/filepath"
-	"strconv"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
 	"github.com/ravvio/easycli-ui/etable"
 	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/export"
 	"github.com/ravvio/noty/flags"
+	"github.com/ravvio/noty/grouping"
 	"github.com/ravvio/noty/notion"
 	"github.com/ravvio/noty/ui"
 	"github.com/ravvio/noty/utils"
 )
 
-type TaskGroupingValues struct {
-	Count int
-	Hours float64
-}
-
 // Table column names
 var (
 	keyId          = "id"
@@ -37,6 +33,7 @@ var (
 	keyEstimate    = "estimate"
 	keyCreatedTime = "createdTime"
 	keyStoryURL    = "storyURL"
+	keySprint      = "sprint"
 	keyCount       = "count"
 )
 
@@ -47,65 +44,47 @@ var taskColumns = map[string]etable.TableColumn{
 	keyName:     etable.NewTableColumn(keyName, "Name").WithMaxWidth(40),
 	keyAssignee: etable.NewTableColumn(keyAssignee, "Assignee"),
 	keyReviewer: etable.NewTableColumn(keyReviewer, "Reviewer"),
-	keyStatus: etable.NewTableColumn(keyStatus, "Status").WithValueFunc(
-		func(value string) string {
-			if config.UseEmotes() {
-				emote := config.StatusEmote(value)
-				if emote != "" {
-					value = fmt.Sprintf("%s %s", emote, value)
-				}
-			}
-			return value
-		},
-	),
+	keyStatus:   etable.NewTableColumn(keyStatus, "Status").WithStyleFunc(StatusStyle),
 	keyEstimate: etable.NewTableColumn(keyEstimate, "Estimate").WithAlignment(etable.TableAlignmentRight),
 	keyPriority: etable.NewTableColumn(keyPriority, "Priority").WithStyleFunc(
 		func(style lipgloss.Style, value string) lipgloss.Style {
-			switch value {
-			case "High":
-				return style.Foreground(ui.PriorityHigh)
-			case "Medium":
-				return style.Foreground(ui.PriorityMedium)
-			case "Low":
-				return style.Foreground(ui.PriorityLow)
+			if color, ok := PriorityColor(value); ok {
+				return style.Foreground(color)
 			}
 			return style
 		},
 	),
 	keyStoryURL:    etable.NewTableColumn(keyStoryURL, "URL"),
+	keySprint:      etable.NewTableColumn(keySprint, "Sprint"),
 	keyCreatedTime: etable.NewTableColumn(keyCreatedTime, "Created"),
 }
 
 func init() {
-	// Users
-	TaskCmd.Flags().StringSliceP("users", "u", []string{}, "filter tasks by users (assignee or reviewer)")
-	TaskCmd.Flags().StringSliceP("assignees", "a", []string{}, "filter tasks by assignees")
-	TaskCmd.Flags().StringSliceP("reviewers", "r", []string{}, "filter tasks by reviewers")
-	TaskCmd.MarkFlagsMutuallyExclusive("assignees", "users")
-	TaskCmd.MarkFlagsMutuallyExclusive("reviewers", "users")
+	TaskCmd.AddCommand(ShowCmd)
+	TaskCmd.AddCommand(CommentsCmd)
+	TaskCmd.AddCommand(CommentCmd)
 
-	// Project
-	TaskCmd.Flags().StringSliceP("project", "p", []string{}, "filter by project(s)")
-
-	// Statu VerbosityLevels
-	TaskCmd.Flags().StringSliceP("status", "s", []string{}, "filter tasks by status(es) [NS, P, TBT, T, D, ND]")
-
-	// Sprint
-	TaskCmd.Flags().Var(
-		flags.StringChoiceOrInt([]string{"default", "all", "backlog", "current", "next"}, "default"),
-		"sprint",
-		"sprint to search tasks in, by default ingnores backlog [all, default, backlog, current, <ID>]",
-	)
+	AddFilterFlags(TaskCmd, "default")
 
 	// Grouping
 	TaskCmd.Flags().VarP(
-		flags.StringChoice(
-			[]string{"assignee", "project"},
-			"",
+		flags.StringChoiceSlice(
+			GroupChoices,
+			[]string{},
 		),
 		"group-by",
 		"g",
-		"define if and how to group data [assignee]",
+		fmt.Sprintf("group data by one or more comma separated dimensions, e.g. project,assignee %v", GroupChoices),
+	)
+	TaskCmd.Flags().String(
+		"pivot",
+		"",
+		fmt.Sprintf("show a matrix of two dimensions as <rows>:<columns>, e.g. assignee:status %v", GroupChoices),
+	)
+	TaskCmd.Flags().Var(
+		flags.StringChoice(pivotValueChoices, "count"),
+		"pivot-value",
+		fmt.Sprintf("value of the cells of the pivot matrix %v", pivotValueChoices),
 	)
 
 	// Limits
@@ -139,171 +118,81 @@ func init() {
 	TaskCmd.Flags().Bool("show-url", false, "add the url of the task page to the output table")
 
 	// Export
-	TaskCmd.Flags().StringP("outfile", "o", "", "export result as csv")
+	TaskCmd.Flags().StringP("outfile", "o", "", "export result as xlsx, json, md or csv, by extension")
+
+	// Watch
+	TaskCmd.Flags().Duration("watch", 0, "re-run the query every given interval (e.g. 30s) highlighting changes")
+	TaskCmd.MarkFlagsMutuallyExclusive("watch", "outfile")
 }
 
 var TaskCmd = &cobra.Command{
 	Use:   "task",
 	Short: "",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		notionClient := notion.NewClient()
 
 		// Load config
-		projectsList := config.Projects()
 		projectsMap := config.ProjectsMap()
 		timeFormat := config.DatetimeFormat()
 
 		// Create filter
-		filter := notion.TaskFilter{}
-
-		// Assignee Flag
-		if assignees, err := cmd.Flags().GetStringSlice("assignees"); err != nil {
+		filter, err := ParseFilterFlags(ctx, cmd)
+		if err != nil {
 			return err
-		} else if len(assignees) != 0 {
-			for _, user := range config.ParseUsers(assignees) {
-				filter.Assignees = append(filter.Assignees, user.ID)
-			}
 		}
-		// Reviewer Flag
-		if reviewers, err := cmd.Flags().GetStringSlice("reviewers"); err != nil {
+		if filter.Sprint, err = SprintFilter(ctx, cmd, notionClient); err != nil {
 			return err
-		} else if len(reviewers) != 0 {
-			for _, user := range config.ParseUsers(reviewers) {
-				filter.Reviewers = append(filter.Reviewers, user.ID)
-			}
 		}
-		// User Flag
-		if usernames, err := cmd.Flags().GetStringSlice("users"); err != nil {
-			return err
-		} else if len(usernames) != 0 {
-			for _, user := range config.ParseUsers(usernames) {
-				filter.Users = append(filter.Users, user.ID)
+
+		// All / Limit Flag
+		limit := -1
+		if all, err := cmd.Flags().GetBool("all"); err != nil {
+			return fmt.Errorf("failed request: %s", err)
+		} else if !all {
+			if limit, err = cmd.Flags().GetInt("limit"); err != nil {
+				return err
 			}
 		}
 
-		// Projects Flag
-		if projects, err := cmd.Flags().GetStringSlice("project"); err != nil {
+		// Grouping Flag
+		// Titles of the sprints of tasks, resolved at each refresh
+		var sprintTitles map[string]string
+		dimensions := TaskDimensions(
+			projectsMap,
+			func(ids []string) string { return notion.JoinTitles(ids, sprintTitles) },
+		)
+		groupBy, err := cmd.Flags().GetStringSlice("group-by")
+		if err != nil {
 			return err
-		} else if len(projects) > 0 {
-			for _, projectName := range projects {
-				l := len(filter.Projects)
-
-				projectName = strings.ToLower(projectName)
-				for _, project := range projectsList {
-					if strings.Contains(
-						strings.ToLower(project.Name),
-						projectName,
-					) {
-						filter.Projects = append(filter.Projects, project.ID)
-					}
-				}
-
-				if l == len(filter.Projects) {
-					return fmt.Errorf("no project found for '%s'", projectName)
-				}
-			}
 		}
-		// Status Flag
-		statuses, err := cmd.Flags().GetStringSlice("status")
+		dims, err := ParseGrouping(groupBy, dimensions)
 		if err != nil {
 			return err
 		}
-		if len(statuses) > 0 {
-			for _, status := range statuses {
-				switch strings.ToUpper(status) {
-				case "NS":
-					filter.Statuses = append(filter.Statuses, notion.StatusNotStarted)
-				case "P":
-					filter.Statuses = append(filter.Statuses, notion.StatusInProgress)
-				case "TBT":
-					filter.Statuses = append(filter.Statuses, notion.StatusToBeTested)
-				case "T":
-					filter.Statuses = append(filter.Statuses, notion.StatusInTesting)
-				case "D":
-					filter.Statuses = append(filter.Statuses, notion.StatusDone)
-				case "ND":
-					filter.Statuses = append(filter.Statuses, notion.StatusNotDone)
-				default:
-					return fmt.Errorf("unknown status '%s', valid values are [NS, P, TBT, T, D]", status)
-				}
-			}
-		}
 
-		// Sprint Flag
-		if sprint, err := cmd.Flags().GetString("sprint"); err != nil {
+		// Pivot Flag
+		var pivot []grouping.Dimension[notion.Task]
+		if value, err := cmd.Flags().GetString("pivot"); err != nil {
 			return err
-		} else if sprint == "default" {
-			filter.Sprint = notion.TaskSprintNoBacklog{}
-		} else if sprint == "all" {
-			filter.Sprint = nil
-		} else if sprint == "backlog" {
-			filter.Sprint = notion.TaskSprintOnlyBacklog{}
-		} else if sprint == "current" || sprint == "next" {
-			// Fetch sprint
-			var s string
-			switch sprint {
-			case "current":
-				s = "Current"
-			case "next":
-				s = "Next"
-			}
-
-			sprintFetcher := notionClient.NewSprintFetcher(
-				ctx,
-				config.SprintsDatabaseID(),
-				notion.SprintFilter{
-					Status: &s,
-				},
-			)
-			res, err := sprintFetcher.NextOne()
-			if err != nil {
-				return err
-			}
-			// Set ID as filter
-			filter.Sprint = notion.TaskSprintByID{
-				ID: res.ID,
-			}
-		} else if sprintId, err := strconv.Atoi(sprint); err == nil {
-			// Fetch sprint
-			id := sprintId + 1
-			sprintFetcher := notionClient.NewSprintFetcher(
-				ctx,
-				config.SprintsDatabaseID(),
-				notion.SprintFilter{
-					ID: &id,
-				},
-			)
-			res, err := sprintFetcher.NextOne()
-			if err != nil {
+		} else if value != "" {
+			if pivot, err = ParsePivot(value, dimensions); err != nil {
 				return err
 			}
-			// Set ID as filter
-			filter.Sprint = notion.TaskSprintByID{
-				ID: res.ID,
-			}
 		}
-
-		// Create fetcher
-		taskFetcher := notionClient.NewTaskFetcher(
-			ctx,
-			config.TasksDatabaseID(),
-			filter,
-		)
-
-		// All / Limit Flag
-		if all, err := cmd.Flags().GetBool("all"); err != nil {
-			return fmt.Errorf("failed request: %s", err)
-		} else if !all {
-			if limit, err := cmd.Flags().GetInt("limit"); err != nil {
-				return err
-			} else {
-				taskFetcher = taskFetcher.WithLimit(limit)
-			}
+		pivotValue, err := cmd.Flags().GetString("pivot-value")
+		if err != nil {
+			return err
 		}
+		needsSprints := slices.Contains(groupBy, "sprint") ||
+			slices.ContainsFunc(pivot, func(dim grouping.Dimension[notion.Task]) bool { return dim.Key == keySprint })
 
-		// Fetch
-		tasks, err := taskFetcher.All()
+		// Watch Flag
+		watch, err := cmd.Flags().GetDuration("watch")
+		if err != nil {
+			return err
+		}
+		quiet, err := cmd.Flags().GetBool("quiet")
 		if err != nil {
 			return err
 		}
@@ -338,125 +227,239 @@ var TaskCmd = &cobra.Command{
 			columns = append(columns, taskColumns[key])
 		}
 
-		// Add rows
-		rows := make([]etable.TableRow, 0, len(tasks))
-		for _, task := range tasks {
-			project := ""
-			if task.ProjectID != nil {
-				project = projectsMap[*task.ProjectID]
+		// Tasks of the previous refresh in watch mode
+		var previousTasks map[string]notion.Task
+
+		for {
+			// Create fetcher
+			taskFetcher := notionClient.NewTaskFetcher(
+				ctx,
+				config.TasksDatabaseID(),
+				filter,
+			)
+			if limit >= 0 {
+				taskFetcher = taskFetcher.WithLimit(limit)
 			}
-			rows = append(rows, etable.TableRow{
-				keyId:          task.ID,
-				keyStoryId:     fmt.Sprintf("STORY-%d", task.StoryID),
-				keyProject:     project,
-				keyName:        task.Name,
-				keyAssignee:    task.Assignee,
-				keyReviewer:    task.Reviewer,
-				keyStatus:      task.Status,
-				keyEstimate:    fmt.Sprintf("%.1f h", task.Estimate),
-				keyPriority:    task.Priority,
-				keyStoryURL:    task.URL,
-				keyCreatedTime: task.Created.Local().Format(timeFormat),
-			})
-		}
 
-		// Render result
-		table := etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows)
-		fmt.Println()
-		fmt.Println(table.Render())
+			// Fetch
+			progress := ui.NewFetchProgress("Fetching tasks", !quiet)
+			taskFetcher = taskFetcher.WithProgress(progress.Update)
+			tasks, err := taskFetcher.All()
+			progress.Stop()
+			interrupted := taskFetcher.Interrupted()
+			if err != nil && !interrupted {
+				return err
+			}
 
-		resultLog := fmt.Sprintf("\nFetched %d tasks", len(rows))
-		if taskFetcher.HasMore() {
-			resultLog += ", has more"
-		}
-		ui.PrintlnInfo(resultLog)
+			// Resolve relations
+			if (slices.Contains(columnKeys, keySprint) || needsSprints) && !interrupted {
+				ids := make([]string, 0)
+				for _, task := range tasks {
+					ids = append(ids, task.SprintIDs...)
+				}
+				if sprintTitles, err = notionClient.PageTitles(ctx, ids); err != nil {
+					return err
+				}
+			}
 
-		// Export
-		if outfile, err := cmd.Flags().GetString("outfile"); err != nil {
-			return err
-		} else if outfile != "" {
-			abs, err := filepath.Abs(outfile)
-			if err != nil {
-				return err
+			// Add rows
+			changed := 0
+			rows := make([]etable.TableRow, 0, len(tasks))
+			for _, task := range tasks {
+				project := ""
+				if task.ProjectID != nil {
+					project = projectsMap[*task.ProjectID]
+				}
+				row := etable.TableRow{
+					keyId:          task.ID,
+					keyStoryId:     fmt.Sprintf("STORY-%d", task.StoryID),
+					keyProject:     project,
+					keyName:        task.Name,
+					keyAssignee:    task.Assignee,
+					keyReviewer:    task.Reviewer,
+					keyStatus:      statusValue(task.Status),
+					keyEstimate:    fmt.Sprintf("%.1f h", task.Estimate),
+					keyPriority:    task.Priority,
+					keyStoryURL:    task.URL,
+					keyCreatedTime: task.Created.Local().Format(timeFormat),
+					keySprint:      notion.JoinTitles(task.SprintIDs, sprintTitles),
+				}
+				if previousTasks != nil && highlightTaskChanges(row, task, previousTasks) {
+					changed++
+				}
+				rows = append(rows, row)
 			}
-			fd, err := os.Create(abs)
-			if err != nil {
-				return err
+
+			// Render result
+			table := etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows)
+			if watch > 0 {
+				ui.ClearScreen()
+				ui.PrintlnfInfo(
+					"Every %s, last refresh at %s",
+					watch,
+					time.Now().Format(timeFormat),
+				)
 			}
+			fmt.Println()
+			fmt.Println(table.Render())
 
-			err = table.ExportCSV(fd)
-			if err != nil {
-				ui.PrintlnfWarn("Could not export to CSV: %s", err.Error())
+			if interrupted {
+				ui.PrintlnfWarn("\nInterrupted, %d rows fetched", len(rows))
 			} else {
-				ui.PrintlnfInfo("Data exported to CSV file %s", abs)
+				resultLog := fmt.Sprintf("\nFetched %d tasks", len(rows))
+				if taskFetcher.HasMore() {
+					resultLog += ", has more"
+				}
+				if previousTasks != nil {
+					resultLog += fmt.Sprintf(", %d changed since last refresh", changed)
+				}
+				ui.PrintlnInfo(resultLog)
 			}
-		}
 
-		// Grouping
-		if grouping, err := cmd.Flags().GetString("group-by"); err != nil {
-			return err
-		} else if grouping != "" {
-			// Define grouping paramteres
-			var groupKey string
-			var groupTitle string
-			var getGroupKeyValue func(task notion.Task) string
-
-			switch grouping {
-			case "assignee":
-				groupKey = keyAssignee
-				groupTitle = "Assignee"
-				getGroupKeyValue = func(task notion.Task) string { return task.Assignee }
-			case "project":
-				groupKey = keyProject
-				groupTitle = "Project"
-				getGroupKeyValue = func(task notion.Task) string {
-					if task.ProjectID != nil {
-						return projectsMap[*task.ProjectID]
-					}
-					return ""
-				}
+			// Grouping
+			var groupColumns []etable.TableColumn
+			var groupRows []etable.TableRow
+			if len(dims) > 0 {
+				groupColumns, groupRows = GroupingTable(tasks, dims)
+				groupTable := etable.NewTable(groupColumns).WithStyle(tableStyle).WithRows(groupRows)
+
+				// Render result
+				fmt.Println()
+				fmt.Println(groupTable.Render())
 			}
 
-			// Define columns
-			columns := []etable.TableColumn{
-				etable.NewTableColumn(groupKey, groupTitle),
-				etable.NewTableColumn(keyCount, "Count").WithAlignment(etable.TableAlignmentRight),
-				etable.NewTableColumn(keyEstimate, "Estimate").WithAlignment(etable.TableAlignmentRight),
+			// Pivot
+			var pivotColumns []etable.TableColumn
+			var pivotRows []etable.TableRow
+			if len(pivot) > 0 {
+				pivotColumns, pivotRows = PivotTable(tasks, pivot[0], pivot[1], pivotValue)
+				pivotTable := etable.NewTable(pivotColumns).WithStyle(tableStyle).WithRows(pivotRows)
+
+				// Render result
+				fmt.Println()
+				fmt.Println(pivotTable.Render())
 			}
 
-			// Add rows
-			groupingMap := make(map[string]TaskGroupingValues, 0)
-			for _, task := range tasks {
-				key := getGroupKeyValue(task)
-				if r, ok := groupingMap[key]; ok {
-					groupingMap[key] = TaskGroupingValues{
-						Count: r.Count + 1,
-						Hours: r.Hours + task.Estimate,
-					}
+			// Export
+			if outfile, err := cmd.Flags().GetString("outfile"); err != nil {
+				return err
+			} else if outfile != "" && interrupted {
+				ui.PrintlnWarn("Partial results not exported")
+			} else if outfile != "" {
+				abs, err := filepath.Abs(outfile)
+				if err != nil {
+					return err
+				}
+
+				tables := []export.Table{{
+					Name:    "Tasks",
+					Columns: columns,
+					Rows:    rows,
+					Kinds: map[string]export.Kind{
+						"Estimate": export.KindHours,
+						"Created":  export.KindDatetime,
+					},
+				}}
+				if len(dims) > 0 {
+					tables = append(tables, groupingExport(groupColumns, groupRows, dims))
+				}
+				if len(pivot) > 0 {
+					tables = append(tables, pivotExport(pivotColumns, pivotRows, pivot[0], pivotValue))
+				}
+				paths, err := export.Write(abs, tables, config.ExportLayouts())
+				if err != nil {
+					ui.PrintlnfWarn("Could not export data: %s", err.Error())
 				} else {
-					groupingMap[key] = TaskGroupingValues{
-						Count: 1,
-						Hours: task.Estimate,
-					}
+					ui.PrintlnfInfo("Data exported to %s", strings.Join(paths, ", "))
 				}
 			}
 
-			rows := make([]etable.TableRow, 0, len(groupingMap))
-			for groupValue, values := range groupingMap {
-				rows = append(rows, etable.TableRow{
-					groupKey:    groupValue,
-					keyCount:    fmt.Sprintf("%d", values.Count),
-					keyEstimate: fmt.Sprintf("%.1f h", values.Hours),
-				})
+			// Partial results exit with the error of the interruption
+			if interrupted {
+				return ctx.Err()
+			}
+			if watch <= 0 {
+				return nil
 			}
 
-			// Render result
-			fmt.Println()
-			fmt.Println(
-				etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows).Render(),
-			)
+			previousTasks = make(map[string]notion.Task, len(tasks))
+			for _, task := range tasks {
+				previousTasks[task.ID] = task
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(watch):
+			}
 		}
-
-		return nil
 	},
 }
+
+// statusValue decorates a status with its emote when enabled.
+func statusValue(status string) string {
+	if config.UseEmotes() {
+		emote := config.StatusEmote(status)
+		if emote != "" {
+			status = fmt.Sprintf("%s %s", emote, status)
+		}
+	}
+	return status
+}
+
+// PriorityColor returns the color of a priority, reports false for unknown
+// priorities.
+func PriorityColor(priority string) (lipgloss.Color, bool) {
+	switch priority {
+	case "High":
+		return ui.PriorityHigh, true
+	case "Medium":
+		return ui.PriorityMedium, true
+	case "Low":
+		return ui.PriorityLow, true
+	}
+	return "", false
+}
+
+// StatusStyle colors a status value, optionally decorated with its emote,
+// with the color of the status.
+func StatusStyle(style lipgloss.Style, value string) lipgloss.Style {
+	for _, status := range config.Statuses() {
+		if value == status.Name || value == statusValue(status.Name) {
+			if color, ok := ui.ColorOf(status.Color); ok {
+				return style.Foreground(color)
+			}
+			break
+		}
+	}
+	return style
+}
+
+// highlightTaskChanges highlights the values of row that changed with
+// respect to the previous version of the task, new tasks have their ID
+// highlighted. Reports whether the task changed.
+func highlightTaskChanges(
+	row etable.TableRow,
+	task notion.Task,
+	previousTasks map[string]notion.Task,
+) bool {
+	previous, ok := previousTasks[task.ID]
+	if !ok {
+		row[keyStoryId] = ui.HighlightStyle.Render(row[keyStoryId])
+		return true
+	}
+
+	changed := false
+	if previous.Status != task.Status {
+		row[keyStatus] = ui.HighlightStyle.Render(row[keyStatus])
+		changed = true
+	}
+	if previous.Assignee != task.Assignee {
+		row[keyAssignee] = ui.HighlightStyle.Render(row[keyAssignee])
+		changed = true
+	}
+	if previous.Estimate != task.Estimate {
+		row[keyEstimate] = ui.HighlightStyle.Render(row[keyEstimate])
+		changed = true
+	}
+	return changed
+}
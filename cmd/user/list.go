@@ -0,0 +1,65 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+)
+
+var ListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the users cached in the configuration",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		users := config.Users()
+		me := config.Me()
+		slices.SortFunc(users, func(a, b notion.NotionUser) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		columns := []etable.TableColumn{
+			etable.NewTableColumn(keyName, "Name"),
+			etable.NewTableColumn(keyId, "ID"),
+		}
+
+		// Add rows
+		rows := make([]etable.TableRow, 0, len(users))
+		for _, user := range users {
+			name := user.Name
+			if me != "" && name == me {
+				name += " (me)"
+			}
+			rows = append(rows, etable.TableRow{
+				keyName: name,
+				keyId:   user.ID,
+			})
+		}
+
+		// Render result
+		fmt.Println()
+		fmt.Println(etable.NewTable(columns).WithStyle(tableStyle).WithRows(rows).Render())
+		ui.PrintlnfInfo("\nFound %d users", len(users))
+
+		return nil
+	},
+}
@@ -0,0 +1,182 @@
+package user
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ravvio/easycli-ui/etable"
+	"github.com/ravvio/noty/cmd/task"
+	"github.com/ravvio/noty/config"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/utils"
+)
+
+// Roles of a user in a task
+const (
+	roleAssignee = "assignee"
+	roleReviewer = "reviewer"
+)
+
+var ShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "show the workload of a user",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		notionClient := notion.NewClient()
+
+		// Load config
+		projectsMap := config.ProjectsMap()
+		dateFormat := config.DateFormat()
+
+		user, err := config.ParseUser(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Fetch current sprint, if any, open tasks and hours of the last 7 days
+		now := time.Now()
+		since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -6)
+
+		var sprint *notion.Sprint
+		var tasks []notion.Task
+		var hoursEntries []notion.HoursEntry
+		group, groupCtx := notionClient.NewGroup(ctx)
+		group.Go(func() error {
+			var err error
+			sprint, err = task.FetchSprint(groupCtx, notionClient, "current")
+			if errors.Is(err, notion.ErrNoData) {
+				return nil
+			}
+			return err
+		})
+		notion.FetchAll(
+			group,
+			notionClient.NewTaskFetcher(
+				groupCtx,
+				config.TasksDatabaseID(),
+				notion.TaskFilter{
+					Users:    []string{user.ID},
+					Statuses: config.OpenStatuses(),
+				},
+			),
+			&tasks,
+		)
+		notion.FetchAll(
+			group,
+			notionClient.NewHoursFetcher(
+				groupCtx,
+				config.HoursDatabaseID(),
+				notion.HoursFilter{
+					Users: []string{user.ID},
+					Date: notion.HoursDateSince{
+						Date: since,
+					},
+				},
+			),
+			&hoursEntries,
+		)
+		if err := group.Wait(); err != nil {
+			return err
+		}
+
+		// Setup table
+		var tableStyle etable.TableStyle
+		if style, err := cmd.Flags().GetString("style"); err != nil {
+			return err
+		} else {
+			switch style {
+			case "md":
+				tableStyle = etable.TableStyleMarkdown
+			default:
+				tableStyle = etable.TableStyleDefault
+			}
+		}
+
+		// Tasks table
+		slices.SortStableFunc(tasks, func(a, b notion.Task) int {
+			return cmp.Compare(a.StoryID, b.StoryID)
+		})
+		taskColumns := []etable.TableColumn{
+			etable.NewTableColumn(keyStoryId, "Story ID"),
+			etable.NewTableColumn(keyProject, "Project"),
+			etable.NewTableColumn(keyName, "Name").WithMaxWidth(40),
+			etable.NewTableColumn(keyRole, "Role"),
+			etable.NewTableColumn(keyStatus, "Status"),
+			etable.NewTableColumn(keyEstimate, "Estimate").WithAlignment(etable.TableAlignmentRight),
+		}
+		taskRows := make([]etable.TableRow, 0, len(tasks))
+		assigned, reviewing := 0, 0
+		remaining := 0.0
+		for _, t := range tasks {
+			role := roleReviewer
+			if t.IsAssignee(user.ID) {
+				role = roleAssignee
+				assigned++
+				if sprint != nil && slices.Contains(t.SprintIDs, sprint.ID) {
+					remaining += t.Estimate
+				}
+			} else {
+				reviewing++
+			}
+
+			project := ""
+			if t.ProjectID != nil {
+				project = projectsMap[*t.ProjectID]
+			}
+			taskRows = append(taskRows, etable.TableRow{
+				keyStoryId:  fmt.Sprintf("STORY-%d", t.StoryID),
+				keyProject:  project,
+				keyName:     t.Name,
+				keyRole:     role,
+				keyStatus:   t.Status,
+				keyEstimate: fmt.Sprintf("%.1f h", t.Estimate),
+			})
+		}
+
+		// Hours table
+		total := 0.0
+		projectHours := make(map[string]float64)
+		for _, entry := range hoursEntries {
+			project := "-"
+			if entry.ProjectID != nil {
+				project = projectsMap[*entry.ProjectID]
+			}
+			projectHours[project] += entry.Hours
+			total += entry.Hours
+		}
+		projects := utils.MapKeys(projectHours)
+		slices.Sort(projects)
+
+		hoursColumns := []etable.TableColumn{
+			etable.NewTableColumn(keyProject, "Project"),
+			etable.NewTableColumn(keyHours, "Hours").WithAlignment(etable.TableAlignmentRight),
+		}
+		hoursRows := make([]etable.TableRow, 0, len(projects))
+		for _, project := range projects {
+			hoursRows = append(hoursRows, etable.TableRow{
+				keyProject: project,
+				keyHours:   fmt.Sprintf("%.1f h", projectHours[project]),
+			})
+		}
+
+		// Render result
+		fmt.Printf("\n%s\n\n", user.Name)
+		fmt.Printf("Open tasks: %d as assignee, %d as reviewer\n", assigned, reviewing)
+		if sprint != nil {
+			fmt.Printf("Remaining in %s: %.1f h\n", sprint.Name, remaining)
+		}
+		fmt.Printf("Hours since %s: %.1f h\n", since.Format(dateFormat), total)
+		fmt.Println()
+		fmt.Println(etable.NewTable(taskColumns).WithStyle(tableStyle).WithRows(taskRows).Render())
+		fmt.Println()
+		fmt.Println(etable.NewTable(hoursColumns).WithStyle(tableStyle).WithRows(hoursRows).Render())
+
+		return nil
+	},
+}
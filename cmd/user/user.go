@@ -0,0 +1,27 @@
+package user
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Table column names
+var (
+	keyId       = "id"
+	keyName     = "name"
+	keyStoryId  = "storyId"
+	keyProject  = "project"
+	keyRole     = "role"
+	keyStatus   = "status"
+	keyEstimate = "estimate"
+	keyHours    = "hours"
+)
+
+func init() {
+	UserCmd.AddCommand(ListCmd)
+	UserCmd.AddCommand(ShowCmd)
+}
+
+var UserCmd = &cobra.Command{
+	Use:   "user",
+	Short: "show users and their workload",
+}
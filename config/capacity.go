@@ -0,0 +1,93 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+const (
+	KeyHoursPerDay = "hours_per_day"
+	KeyCapacities  = "capacities"
+	KeyDaysOff     = "days_off"
+	KeyHolidays    = "holidays"
+)
+
+// HoursPerDay returns the default working hours per day of users.
+func HoursPerDay() float64 {
+	return viper.GetFloat64(KeyHoursPerDay)
+}
+
+// Capacities returns the configured working hours per day of users, by user
+// ID.
+func Capacities(ctx context.Context) (map[string]float64, error) {
+	capacities, err := byUserID(ctx, KeyCapacities, viper.GetStringMap(KeyCapacities))
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]float64, len(capacities))
+	for id, hours := range capacities {
+		res[id] = cast.ToFloat64(hours)
+	}
+	return res, nil
+}
+
+// DaysOff returns the configured days off of users, by user ID.
+func DaysOff(ctx context.Context) (map[string][]time.Time, error) {
+	daysOff, err := byUserID(ctx, KeyDaysOff, viper.GetStringMapStringSlice(KeyDaysOff))
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string][]time.Time, len(daysOff))
+	for id, values := range daysOff {
+		res[id], err = parseDates(values)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// byUserID rekeys the values of a per user setting by user ID. Settings are
+// keyed either by user ID, which survives renames in notion, or by a name or
+// alias as accepted by ParseUser.
+func byUserID[T any](ctx context.Context, key string, values map[string]T) (map[string]T, error) {
+	ids := make(map[string]bool)
+	for _, user := range Users() {
+		ids[user.ID] = true
+	}
+
+	res := make(map[string]T, len(values))
+	for name, value := range values {
+		id := name
+		if !ids[id] {
+			user, err := ParseUser(ctx, name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid user in '%s': %w", key, err)
+			}
+			id = user.ID
+		}
+		res[id] = value
+	}
+	return res, nil
+}
+
+// Holidays returns the configured days off for everyone.
+func Holidays() ([]time.Time, error) {
+	return parseDates(viper.GetStringSlice(KeyHolidays))
+}
+
+func parseDates(values []string) ([]time.Time, error) {
+	dates := make([]time.Time, 0, len(values))
+	for _, value := range values {
+		date, err := time.Parse(DateFormat(), value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date '%s' in configuration, expected format %s", value, DateFormat())
+		}
+		dates = append(dates, date)
+	}
+	return dates, nil
+}
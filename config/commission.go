@@ -0,0 +1,41 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ravvio/noty/notion"
+)
+
+// ParseCommission returns the commission best matching name, see
+// ParseCommissions.
+func ParseCommission(ctx context.Context, name string) (notion.Commission, error) {
+	if CommissionsDatabaseID() == "" {
+		return notion.Commission{}, fmt.Errorf(
+			"commissions database not configured, run 'noty config set %s <id>'",
+			KeyCommissionsDatabaseID,
+		)
+	}
+	return resolveRefreshing(
+		ctx,
+		"commission",
+		name,
+		Commissions,
+		func(c notion.Commission) string { return c.Name },
+		nil,
+	)
+}
+
+// ParseCommissions returns the commissions best matching names, with the
+// same rules used for users by ParseUsers.
+func ParseCommissions(ctx context.Context, names []string) ([]notion.Commission, error) {
+	commissions := make([]notion.Commission, 0, len(names))
+	for _, name := range names {
+		commission, err := ParseCommission(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		commissions = append(commissions, commission)
+	}
+	return commissions, nil
+}
@@ -5,24 +5,39 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
+	"github.com/ravvio/noty/export"
 	"github.com/ravvio/noty/notion"
 	"github.com/spf13/viper"
 )
 
 const (
-	KeyTasksDatabaseID    = "tasks_database_id"
-	KeyProjectsDatabaseID = "projects_database_id"
-	KeySprintsDatabaseID  = "sprints_database_id"
-	KeyHoursDatabaseID    = "hours_database_id"
-	KeyUsers              = "users"
-	KeyProjects           = "projects"
-	KeyUseEmotes          = "use_emotes"
-	KeyStatusEmotes       = "status_emotes"
-	KeyDatetimeFormat     = "datetime_format"
-	KeyDateFormat         = "date_format"
+	KeyTasksDatabaseID       = "tasks_database_id"
+	KeyProjectsDatabaseID    = "projects_database_id"
+	KeySprintsDatabaseID     = "sprints_database_id"
+	KeyHoursDatabaseID       = "hours_database_id"
+	KeyCommissionsDatabaseID = "commissions_database_id"
+	KeyUsers                 = "users"
+	KeyProjects              = "projects"
+	KeyCommissions           = "commissions"
+	KeyUseEmotes             = "use_emotes"
+	KeyStatusEmotes          = "status_emotes"
+	KeyDatetimeFormat        = "datetime_format"
+	KeyDateFormat            = "date_format"
+	KeyMe                    = "me"
+	KeyMaxWorkers            = "max_concurrent_requests"
+	KeyRequestsPerSecond     = "requests_per_second"
 )
 
+// Prefix of the environment variables overriding configuration values
+const EnvPrefix = "noty"
+
+// EnvName returns the environment variable overriding the value of key.
+func EnvName(key string) string {
+	return strings.ToUpper(EnvPrefix + "_" + key)
+}
+
 func ConfigDir() (string, error) {
 	base, err := os.UserConfigDir()
 	if err != nil {
@@ -36,20 +51,23 @@ func Init() error {
 	viper.SetDefault(KeyProjectsDatabaseID, "")
 	viper.SetDefault(KeySprintsDatabaseID, "")
 	viper.SetDefault(KeyHoursDatabaseID, "")
+	viper.SetDefault(KeyCommissionsDatabaseID, "")
 
 	viper.SetDefault(KeyUseEmotes, true)
-	viper.SetDefault(KeyStatusEmotes, map[string]string{
-		"not_started":  "❄️",
-		"in_progress":  "🚀",
-		"to_be_tested": "💣",
-		"in_testing":   "💥",
-		"done":         "✅",
-		"cancelled":    "❌",
-	})
+	viper.SetDefault(KeyStatuses, statusesValue(defaultStatuses))
+	viper.SetDefault(KeyStatusEmotes, map[string]string{})
+
+	viper.SetDefault(KeyHoursPerDay, 8.0)
+	viper.SetDefault(KeyCacheMaxAge, 7*24*time.Hour)
+	viper.SetDefault(KeyMaxWorkers, notion.DefaultMaxWorkers)
+	viper.SetDefault(KeyRequestsPerSecond, notion.DefaultRequestsPerSecond)
 
 	viper.SetDefault(KeyDatetimeFormat, "2006-01-02 15:04")
 	viper.SetDefault(KeyDateFormat, "2006-01-02")
 
+	viper.SetEnvPrefix(EnvPrefix)
+	viper.AutomaticEnv()
+
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
 	dir, err := ConfigDir()
@@ -113,6 +131,12 @@ func HoursDatabaseID() string {
 	return viper.GetString(KeyHoursDatabaseID)
 }
 
+// CommissionsDatabaseID returns the ID of the commissions database, empty
+// if not configured.
+func CommissionsDatabaseID() string {
+	return viper.GetString(KeyCommissionsDatabaseID)
+}
+
 func UseEmotes() bool {
 	return viper.GetBool(KeyUseEmotes)
 }
@@ -121,8 +145,15 @@ func StatusEmotes() map[string]string {
 	return viper.GetStringMapString(KeyStatusEmotes)
 }
 
+// StatusEmote returns the emote of a status, the ones in status_emotes
+// keyed by the status name in snake case take precedence over the ones of
+// the configured statuses.
 func StatusEmote(value string) string {
-	return StatusEmotes()[strings.ReplaceAll(strings.ToLower(value), " ", "_")]
+	if emote, ok := StatusEmotes()[strings.ReplaceAll(strings.ToLower(value), " ", "_")]; ok {
+		return emote
+	}
+	status, _ := FindStatus(value)
+	return status.Emote
 }
 
 func DatetimeFormat() string {
@@ -133,6 +164,30 @@ func DateFormat() string {
 	return viper.GetString(KeyDateFormat)
 }
 
+// ExportLayouts returns the layouts used to recognize dates in exported
+// tables.
+func ExportLayouts() export.Layouts {
+	return export.Layouts{
+		Date:     DateFormat(),
+		Datetime: DatetimeFormat(),
+	}
+}
+
+// MaxWorkers returns the maximum number of requests made in parallel.
+func MaxWorkers() int {
+	return viper.GetInt(KeyMaxWorkers)
+}
+
+// RequestsPerSecond returns the maximum rate of requests to notion.
+func RequestsPerSecond() float64 {
+	return viper.GetFloat64(KeyRequestsPerSecond)
+}
+
+// Me returns the name of the user running noty, if configured.
+func Me() string {
+	return viper.GetString(KeyMe)
+}
+
 func Users() []notion.NotionUser {
 	users := viper.Get(KeyUsers).([]any)
 	res := make([]notion.NotionUser, 0, len(users))
@@ -146,6 +201,14 @@ func Users() []notion.NotionUser {
 	return res
 }
 
+func UsersMap() map[string]string {
+	res := make(map[string]string)
+	for _, user := range Users() {
+		res[user.ID] = user.Name
+	}
+	return res
+}
+
 func Projects() []notion.Project {
 	projects := viper.Get(KeyProjects).([]any)
 	res := make([]notion.Project, 0, len(projects))
@@ -168,3 +231,26 @@ func ProjectsMap() map[string]string {
 	}
 	return res
 }
+
+// Commissions returns the cached commissions, none if the commissions
+// database is not configured.
+func Commissions() []notion.Commission {
+	commissions, _ := viper.Get(KeyCommissions).([]any)
+	res := make([]notion.Commission, 0, len(commissions))
+	for _, commission := range commissions {
+		m := commission.(map[string]any)
+		res = append(res, notion.Commission{
+			ID:   m["id"].(string),
+			Name: m["name"].(string),
+		})
+	}
+	return res
+}
+
+func CommissionsMap() map[string]string {
+	res := make(map[string]string)
+	for _, commission := range Commissions() {
+		res[commission.ID] = commission.Name
+	}
+	return res
+}
@@ -0,0 +1,366 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ravvio/noty/notion"
+)
+
+// Sources of a configuration value
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+// KeyInfo describes a configuration key that can be read and written with
+// the config command.
+type KeyInfo struct {
+	Key         string
+	Description string
+
+	// Map keys hold a value per sub key, addressed as <key>.<sub key>
+	Map bool
+
+	// ReadOnly keys cannot be set with the config command, SetHint tells
+	// how to change them
+	ReadOnly bool
+	SetHint  string
+
+	// Parse validates a value given as string and converts it to the type
+	// stored in the configuration
+	Parse func(value string) (any, error)
+}
+
+var databaseIDRegexp = regexp.MustCompile(
+	`^[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}$`,
+)
+
+// Keys lists the known configuration keys.
+var Keys = []KeyInfo{
+	{Key: KeyTasksDatabaseID, Description: "ID of the tasks database", Parse: parseDatabaseID},
+	{Key: KeyProjectsDatabaseID, Description: "ID of the projects database", Parse: parseDatabaseID},
+	{Key: KeySprintsDatabaseID, Description: "ID of the sprints database", Parse: parseDatabaseID},
+	{Key: KeyHoursDatabaseID, Description: "ID of the hours entries database", Parse: parseDatabaseID},
+	{Key: KeyCommissionsDatabaseID, Description: "ID of the commissions database, optional", Parse: parseDatabaseID},
+	{Key: KeyUseEmotes, Description: "use emotes in outputs", Parse: parseBool},
+	{
+		Key:         KeyStatuses,
+		Description: "names, codes, emotes, colors and open classification of statuses",
+		ReadOnly:    true,
+		SetHint:     "run 'noty configure statuses' or 'noty config edit'",
+	},
+	{Key: KeyStatusEmotes, Description: "emote overrides by status name, e.g. status_emotes.not_done", Map: true, Parse: parseString},
+	{Key: KeyDateFormat, Description: "Go layout of dates", Parse: parseLayout},
+	{Key: KeyDatetimeFormat, Description: "Go layout of date and times", Parse: parseLayout},
+	{Key: KeyMe, Description: "name of the user running noty", Parse: parseMe},
+	{Key: KeyHoursPerDay, Description: "default working hours per day", Parse: parsePositiveFloat},
+	{Key: KeyCapacities, Description: "working hours per day of each user, by user ID or name", Map: true, Parse: parsePositiveFloat},
+	{Key: KeyDaysOff, Description: "days off of each user, by user ID or name, comma separated", Map: true, Parse: parseDateList},
+	{Key: KeyHolidays, Description: "days off for everyone, comma separated", Parse: parseDateList},
+	{Key: KeyCacheMaxAge, Description: "age after which users and projects are refreshed", Parse: parseDuration},
+	{Key: KeyMaxWorkers, Description: "maximum number of requests made in parallel", Parse: parsePositiveInt},
+	{Key: KeyRequestsPerSecond, Description: "maximum requests per second to notion, 0 for no limit", Parse: parseNonNegativeFloat},
+	{Key: KeyUserAliases, Description: "nicknames of users", Map: true, Parse: parseString},
+	{Key: KeyProjectAliases, Description: "nicknames of projects", Map: true, Parse: parseString},
+	{Key: KeyUsers, Description: "cached users", ReadOnly: true, SetHint: "run 'noty configure refresh'"},
+	{Key: KeyProjects, Description: "cached projects", ReadOnly: true, SetHint: "run 'noty configure refresh'"},
+	{Key: KeyCommissions, Description: "cached commissions", ReadOnly: true, SetHint: "run 'noty configure refresh'"},
+}
+
+// Keys overridden by flags for this run
+var overrides = make(map[string]bool)
+
+// LookupKey returns the description of key, which may address a sub key of
+// a map key.
+func LookupKey(key string) (KeyInfo, error) {
+	key = strings.ToLower(key)
+	base, sub, isSub := strings.Cut(key, ".")
+	i := slices.IndexFunc(Keys, func(info KeyInfo) bool { return info.Key == base })
+	if i < 0 {
+		return KeyInfo{}, fmt.Errorf("unknown configuration key '%s'", key)
+	}
+	info := Keys[i]
+	if isSub && (!info.Map || sub == "") {
+		return KeyInfo{}, fmt.Errorf("configuration key '%s' has no sub keys", base)
+	}
+	return info, nil
+}
+
+// ParseValue validates value for key and converts it to the stored type.
+func ParseValue(key string, value string) (any, error) {
+	info, err := LookupKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if info.ReadOnly {
+		return nil, fmt.Errorf("configuration key '%s' cannot be set, %s", info.Key, info.SetHint)
+	}
+	if info.Map && !strings.Contains(key, ".") {
+		return nil, fmt.Errorf("configuration key '%s' is a map, set its values as '%s.<name>'", info.Key, info.Key)
+	}
+	parsed, err := info.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for '%s': %s", key, err)
+	}
+	return parsed, nil
+}
+
+// Override sets the value of key for this run only.
+func Override(key string, value string) error {
+	parsed, err := ParseValue(key, value)
+	if err != nil {
+		return err
+	}
+	key = strings.ToLower(key)
+	viper.Set(key, parsed)
+	overrides[key] = true
+	return nil
+}
+
+// Source returns where the effective value of key comes from.
+func Source(key string) string {
+	key = strings.ToLower(key)
+	base, _, _ := strings.Cut(key, ".")
+	switch {
+	case overrides[key] || overrides[base]:
+		return SourceFlag
+	case envSet(key) || envSet(base):
+		return SourceEnv
+	case viper.InConfig(key):
+		return SourceFile
+	}
+	return SourceDefault
+}
+
+func envSet(key string) bool {
+	_, ok := os.LookupEnv(EnvName(key))
+	return ok
+}
+
+// SetInFile validates value and writes it for key in the configuration
+// file, leaving values from other sources out of it. Returns the path of
+// the file.
+func SetInFile(key string, value string) (string, error) {
+	parsed, err := ParseValue(key, value)
+	if err != nil {
+		return "", err
+	}
+	return writeInFile(key, parsed)
+}
+
+// writeInFile writes value for key in the configuration file, leaving
+// values from other sources out of it, and reloads the configuration.
+func writeInFile(key string, value any) (string, error) {
+	if viper.ConfigFileUsed() == "" {
+		return "", fmt.Errorf("configuration not found, run the 'configure' command to generate it")
+	}
+	return mergeInFile(map[string]any{key: value})
+}
+
+// mergeInFile writes values in the configuration file, creating it if
+// missing, leaving values from other sources out of it, and reloads the
+// configuration. Returns the path of the file.
+func mergeInFile(values map[string]any) (string, error) {
+	file := viper.New()
+	filename := viper.ConfigFileUsed()
+	if filename != "" {
+		file.SetConfigFile(filename)
+		if err := file.ReadInConfig(); err != nil {
+			return "", err
+		}
+	} else {
+		dir, err := ConfigDir()
+		if err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(dir, 0777); err != nil {
+			return "", err
+		}
+		filename = path.Join(dir, "config.yaml")
+	}
+	for key, value := range values {
+		file.Set(strings.ToLower(key), value)
+	}
+	if err := file.WriteConfigAs(filename); err != nil {
+		return "", err
+	}
+	if _, err := Load(); err != nil {
+		return "", err
+	}
+	return filename, nil
+}
+
+// Validate checks the effective values of all known keys, returning an
+// error for each invalid one.
+func Validate() []error {
+	errs := make([]error, 0)
+	check := func(key string, value string) {
+		info, err := LookupKey(key)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		if _, err := info.Parse(value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value for '%s': %s", key, err))
+		}
+	}
+
+	statuses := make([]Status, 0)
+	if err := viper.UnmarshalKey(KeyStatuses, &statuses); err != nil {
+		errs = append(errs, fmt.Errorf("invalid value for '%s': %s", KeyStatuses, err))
+	} else {
+		errs = append(errs, validateStatuses(statuses)...)
+	}
+
+	for _, info := range Keys {
+		if info.ReadOnly || !viper.IsSet(info.Key) {
+			continue
+		}
+		switch {
+		case info.Map:
+			for sub, value := range viper.GetStringMap(info.Key) {
+				check(info.Key+"."+sub, stringValue(value))
+			}
+		case info.Key == KeyMe && Me() == "":
+		case viper.GetString(info.Key) == "" && strings.HasSuffix(info.Key, "_database_id"):
+		default:
+			check(info.Key, stringValue(viper.Get(info.Key)))
+		}
+	}
+	return errs
+}
+
+// stringValue formats a configuration value as accepted by Parse.
+func stringValue(value any) string {
+	switch v := value.(type) {
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return strings.Join(values, ",")
+	case []string:
+		return strings.Join(v, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+func parseString(value string) (any, error) {
+	return value, nil
+}
+
+func parseBool(value string) (any, error) {
+	return strconv.ParseBool(value)
+}
+
+func parsePositiveFloat(value string) (any, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("must be a number")
+	}
+	if f <= 0 {
+		return nil, fmt.Errorf("must be positive")
+	}
+	return f, nil
+}
+
+func parsePositiveInt(value string) (any, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("must be an integer")
+	}
+	if i <= 0 {
+		return nil, fmt.Errorf("must be positive")
+	}
+	return i, nil
+}
+
+func parseNonNegativeFloat(value string) (any, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("must be a number")
+	}
+	if f < 0 {
+		return nil, fmt.Errorf("must not be negative")
+	}
+	return f, nil
+}
+
+func parseDuration(value string) (any, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, err
+	}
+	return d.String(), nil
+}
+
+func parseDatabaseID(value string) (any, error) {
+	if !databaseIDRegexp.MatchString(value) {
+		return nil, fmt.Errorf("must be a UUID, e.g. 1a2b3c4d-1a2b-1a2b-1a2b-1a2b3c4d5e6f")
+	}
+	return value, nil
+}
+
+// parseLayout accepts Go time layouts that format a time and parse it back.
+func parseLayout(value string) (any, error) {
+	// Any time whose elements differ from the ones of the layout reference
+	reference := time.Date(2013, time.November, 23, 21, 37, 49, 0, time.UTC)
+	formatted := reference.Format(value)
+	if formatted == value {
+		return nil, fmt.Errorf("'%s' contains no date or time element, see https://pkg.go.dev/time#Layout", value)
+	}
+	if _, err := time.Parse(value, formatted); err != nil {
+		return nil, fmt.Errorf("'%s' is not a valid Go time layout: %s", value, err)
+	}
+	return value, nil
+}
+
+func parseDateList(value string) (any, error) {
+	dates := make([]string, 0)
+	for _, date := range strings.Split(value, ",") {
+		date = strings.TrimSpace(date)
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(DateFormat(), date); err != nil {
+			return nil, fmt.Errorf("'%s' is not a date with layout %s", date, DateFormat())
+		}
+		dates = append(dates, date)
+	}
+	return dates, nil
+}
+
+// parseMe matches value with the cached users, without refreshing them so
+// that validating the configuration makes no request.
+func parseMe(value string) (any, error) {
+	if value == "me" {
+		return nil, fmt.Errorf("must be the name of a user")
+	}
+	user, err := resolve(
+		"user",
+		value,
+		Users(),
+		func(u notion.NotionUser) string { return u.Name },
+		UserAliases(),
+	)
+	if err != nil {
+		var noMatch *noMatchError
+		if errors.As(err, &noMatch) {
+			return nil, fmt.Errorf("%s, run 'noty configure refresh' if the user is new", err)
+		}
+		return nil, err
+	}
+	return user.Name, nil
+}
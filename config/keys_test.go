@@ -0,0 +1,53 @@
+package config
+
+import "testing"
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		layout string
+		valid  bool
+	}{
+		{"2006-01-02", true},
+		{"02/01/2006", true},
+		{"2006-01-02 15:04", true},
+		{"Jan 2, 2006", true},
+		{"15:04:05", true},
+		{"", false},
+		{"yyyy-mm-dd", false},
+		{"dd/mm/yyyy", false},
+	}
+	for _, test := range tests {
+		_, err := parseLayout(test.layout)
+		if test.valid && err != nil {
+			t.Errorf("parseLayout(%q) failed: %s", test.layout, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("parseLayout(%q) succeeded, want an error", test.layout)
+		}
+	}
+}
+
+func TestParseDatabaseID(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"1a2b3c4d-1a2b-1a2b-1a2b-1a2b3c4d5e6f", true},
+		{"1a2b3c4d1a2b1a2b1a2b1a2b3c4d5e6f", true},
+		{"1A2B3C4D-1A2B-1A2B-1A2B-1A2B3C4D5E6F", true},
+		{"", false},
+		{"1a2b3c4d-1a2b-1a2b-1a2b", false},
+		{"1a2b3c4d-1a2b-1a2b-1a2b-1a2b3c4d5e6f0", false},
+		{"1a2b3c4d-1a2b-1a2b-1a2b-1a2b3c4d5e6g", false},
+		{"https://www.notion.so/1a2b3c4d1a2b1a2b1a2b1a2b3c4d5e6f", false},
+	}
+	for _, test := range tests {
+		_, err := parseDatabaseID(test.id)
+		if test.valid && err != nil {
+			t.Errorf("parseDatabaseID(%q) failed: %s", test.id, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("parseDatabaseID(%q) succeeded, want an error", test.id)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"context"
+	"github.com/ravvio/noty/notion"
+)
+
+// ParseProject returns the project best matching name, see ParseProjects.
+func ParseProject(ctx context.Context, name string) (notion.Project, error) {
+	return resolveRefreshing(
+		ctx,
+		"project",
+		name,
+		Projects,
+		func(p notion.Project) string { return p.Name },
+		ProjectAliases(),
+	)
+}
+
+// ParseProjects returns the projects best matching names, with the same
+// rules used for users by ParseUsers.
+func ParseProjects(ctx context.Context, names []string) ([]notion.Project, error) {
+	projects := make([]notion.Project, 0, len(names))
+	for _, name := range names {
+		project, err := ParseProject(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ravvio/noty/cache"
+	"github.com/ravvio/noty/notion"
+	"github.com/ravvio/noty/ui"
+)
+
+const KeyCacheMaxAge = "cache_max_age"
+
+// Name of the cached time of the last refresh
+const refreshName = "refresh"
+
+// Whether users and projects were already refreshed by this process
+var refreshed = false
+
+type refreshInfo struct {
+	Time time.Time
+}
+
+// CacheMaxAge returns the age after which the cached users and projects are
+// refreshed, zero disables automatic refreshes.
+func CacheMaxAge() time.Duration {
+	return viper.GetDuration(KeyCacheMaxAge)
+}
+
+// SetUsers stores users in the configuration.
+func SetUsers(users []notion.NotionUser) {
+	viper.Set(KeyUsers, usersValue(users))
+}
+
+func usersValue(users []notion.NotionUser) []any {
+	values := make([]any, 0, len(users))
+	for _, user := range users {
+		values = append(values, map[string]any{
+			"id":   user.ID,
+			"name": user.Name,
+		})
+	}
+	return values
+}
+
+// SetProjects stores projects in the configuration.
+func SetProjects(projects []notion.Project) {
+	viper.Set(KeyProjects, projectsValue(projects))
+}
+
+func projectsValue(projects []notion.Project) []any {
+	values := make([]any, 0, len(projects))
+	for _, project := range projects {
+		values = append(values, map[string]any{
+			"id":   project.ID,
+			"name": project.Name,
+		})
+	}
+	return values
+}
+
+// SetCommissions stores commissions in the configuration.
+func SetCommissions(commissions []notion.Commission) {
+	viper.Set(KeyCommissions, commissionsValue(commissions))
+}
+
+func commissionsValue(commissions []notion.Commission) []any {
+	values := make([]any, 0, len(commissions))
+	for _, commission := range commissions {
+		values = append(values, map[string]any{
+			"id":   commission.ID,
+			"name": commission.Name,
+		})
+	}
+	return values
+}
+
+// FetchUsers fetches all users of the workspace, bots excluded.
+func FetchUsers(ctx context.Context, client *notion.Client) ([]notion.NotionUser, error) {
+	fetcher := client.NewUserFetcher(ctx, true)
+	return fetcher.All()
+}
+
+// FetchProjects fetches all projects of the projects database.
+func FetchProjects(ctx context.Context, client *notion.Client) ([]notion.Project, error) {
+	fetcher := client.NewProjectFetcher(ctx, ProjectsDatabaseID())
+	return fetcher.All()
+}
+
+// FetchCommissions fetches all commissions of the commissions database, none
+// if it is not configured.
+func FetchCommissions(ctx context.Context, client *notion.Client) ([]notion.Commission, error) {
+	if CommissionsDatabaseID() == "" {
+		return nil, nil
+	}
+	fetcher := client.NewCommissionFetcher(ctx, CommissionsDatabaseID())
+	return fetcher.All()
+}
+
+// Refresh fetches users, projects and, if configured, commissions from
+// notion and writes them in the configuration file, leaving all other values
+// untouched.
+func Refresh(ctx context.Context, client *notion.Client) error {
+	users, err := FetchUsers(ctx, client)
+	if err != nil {
+		return err
+	}
+	projects, err := FetchProjects(ctx, client)
+	if err != nil {
+		return err
+	}
+	commissions, err := FetchCommissions(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]any{
+		KeyUsers:    usersValue(users),
+		KeyProjects: projectsValue(projects),
+	}
+	if commissions != nil {
+		values[KeyCommissions] = commissionsValue(commissions)
+	}
+	if _, err := mergeInFile(values); err != nil {
+		return err
+	}
+	MarkRefreshed()
+	return nil
+}
+
+// MarkRefreshed records that users and projects were just refreshed.
+func MarkRefreshed() {
+	refreshed = true
+	if err := cache.Save(refreshName, refreshInfo{Time: time.Now()}); err != nil {
+		ui.PrintlnfWarn("Could not save refresh time: %s", err)
+	}
+}
+
+// RefreshIfStale refreshes users and projects if the last refresh is older
+// than CacheMaxAge.
+func RefreshIfStale(ctx context.Context) error {
+	maxAge := CacheMaxAge()
+	if maxAge <= 0 || refreshed {
+		return nil
+	}
+
+	info := refreshInfo{}
+	if found, err := cache.Load(refreshName, &info); err != nil {
+		ui.PrintlnfWarn("Could not load refresh time: %s", err)
+	} else if found && time.Since(info.Time) < maxAge {
+		return nil
+	}
+
+	ui.PrintlnInfo("Refreshing cached users and projects")
+	return Refresh(ctx, notion.NewClient())
+}
+
+// refreshOnce refreshes users and projects if it was not already done by
+// this process, reports whether they were refreshed.
+func refreshOnce(ctx context.Context) bool {
+	if refreshed {
+		return false
+	}
+	ui.PrintlnInfo("Refreshing cached users and projects")
+	if err := Refresh(ctx, notion.NewClient()); err != nil {
+		ui.PrintlnfWarn("Could not refresh users and projects: %s", err)
+		refreshed = true
+		return false
+	}
+	return true
+}
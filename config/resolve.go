@@ -0,0 +1,164 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/ravvio/noty/utils"
+)
+
+const (
+	KeyUserAliases    = "user_aliases"
+	KeyProjectAliases = "project_aliases"
+)
+
+// Kinds of match of a name, from the weakest to the strongest.
+const (
+	matchNone = iota
+	matchDistance
+	matchSubstring
+	matchWordPrefix
+	matchPrefix
+	matchExact
+)
+
+// UserAliases returns the configured nicknames of users, mapped to their
+// names.
+func UserAliases() map[string]string {
+	return viper.GetStringMapString(KeyUserAliases)
+}
+
+// ProjectAliases returns the configured nicknames of projects, mapped to
+// their names.
+func ProjectAliases() map[string]string {
+	return viper.GetStringMapString(KeyProjectAliases)
+}
+
+// noMatchError is returned when a name matches no candidate.
+type noMatchError struct {
+	kind  string
+	query string
+}
+
+func (err *noMatchError) Error() string {
+	return fmt.Sprintf("no %s found for '%s'", err.kind, err.query)
+}
+
+type match struct {
+	kind     int
+	distance int
+}
+
+// better reports whether m is a stronger match than other.
+func (m match) better(other match) bool {
+	if m.kind != other.kind {
+		return m.kind > other.kind
+	}
+	return m.kind == matchDistance && m.distance < other.distance
+}
+
+// matchName scores how well query, already lowercase, matches name.
+func matchName(query string, name string) match {
+	name = strings.ToLower(name)
+	switch {
+	case name == query:
+		return match{kind: matchExact}
+	case strings.HasPrefix(name, query):
+		return match{kind: matchPrefix}
+	}
+	for _, word := range strings.Fields(name) {
+		if strings.HasPrefix(word, query) {
+			return match{kind: matchWordPrefix}
+		}
+	}
+	if strings.Contains(name, query) {
+		return match{kind: matchSubstring}
+	}
+
+	// Typos are tolerated on names and on single words of names
+	maxDistance := max(1, len([]rune(query))/3)
+	distance := utils.EditDistance(query, name)
+	for _, word := range strings.Fields(name) {
+		distance = min(distance, utils.EditDistance(query, word))
+	}
+	if distance <= maxDistance {
+		return match{kind: matchDistance, distance: distance}
+	}
+	return match{kind: matchNone}
+}
+
+// resolve returns the candidate whose name best matches query, after
+// expanding aliases. Fails with a noMatchError if nothing matches, or if the
+// best match is not unique listing the tied candidates.
+func resolve[T any](
+	kind string,
+	query string,
+	candidates []T,
+	name func(T) string,
+	aliases map[string]string,
+) (T, error) {
+	var zero T
+
+	q := strings.ToLower(strings.TrimSpace(query))
+	if alias, ok := aliases[q]; ok {
+		q = strings.ToLower(alias)
+	}
+	if q == "" {
+		return zero, fmt.Errorf("empty %s name", kind)
+	}
+
+	best := match{kind: matchNone}
+	ties := make([]T, 0)
+	for _, candidate := range candidates {
+		m := matchName(q, name(candidate))
+		if m.kind == matchNone {
+			continue
+		}
+		if m.better(best) {
+			best = m
+			ties = ties[:0]
+		}
+		if !best.better(m) {
+			ties = append(ties, candidate)
+		}
+	}
+
+	switch len(ties) {
+	case 0:
+		return zero, &noMatchError{kind: kind, query: query}
+	case 1:
+		return ties[0], nil
+	}
+	names := make([]string, 0, len(ties))
+	for _, candidate := range ties {
+		names = append(names, name(candidate))
+	}
+	return zero, fmt.Errorf(
+		"'%s' matches more than one %s: %s",
+		query,
+		kind,
+		strings.Join(names, ", "),
+	)
+}
+
+// resolveRefreshing is resolve, retried once after refreshing the cached
+// users and projects if nothing matches.
+func resolveRefreshing[T any](
+	ctx context.Context,
+	kind string,
+	query string,
+	candidates func() []T,
+	name func(T) string,
+	aliases map[string]string,
+) (T, error) {
+	res, err := resolve(kind, query, candidates(), name, aliases)
+	var noMatch *noMatchError
+	if errors.As(err, &noMatch) && refreshOnce(ctx) {
+		return resolve(kind, query, candidates(), name, aliases)
+	}
+	return res, err
+}
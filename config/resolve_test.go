@@ -0,0 +1,69 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	names := []string{"Mario Rossi", "Maria Bianchi", "Luca Verdi", "Luca Neri", "Anna"}
+	aliases := map[string]string{"boss": "Luca Verdi"}
+
+	tests := []struct {
+		query string
+		want  string
+		ties  []string
+	}{
+		// Exact names win over prefixes of other names
+		{query: "anna", want: "Anna"},
+		{query: "MARIO ROSSI", want: "Mario Rossi"},
+		// Prefixes win over prefixes of words
+		{query: "mari", ties: []string{"Mario Rossi", "Maria Bianchi"}},
+		{query: "mario", want: "Mario Rossi"},
+		// Prefixes of words win over substrings
+		{query: "verd", want: "Luca Verdi"},
+		{query: "anchi", want: "Maria Bianchi"},
+		// Typos are tolerated, the closest name wins
+		{query: "marco", want: "Mario Rossi"},
+		{query: "nerri", want: "Luca Neri"},
+		// Aliases are expanded before matching
+		{query: "Boss", want: "Luca Verdi"},
+		// Equally good matches are ambiguous
+		{query: "luca", ties: []string{"Luca Verdi", "Luca Neri"}},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			got, err := resolve("user", test.query, names, func(s string) string { return s }, aliases)
+			if test.ties != nil {
+				if err == nil {
+					t.Fatalf("resolve(%q) = %q, want an ambiguity error", test.query, got)
+				}
+				for _, name := range test.ties {
+					if !strings.Contains(err.Error(), name) {
+						t.Errorf("error %q does not list %q", err, name)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolve(%q) failed: %s", test.query, err)
+			}
+			if got != test.want {
+				t.Errorf("resolve(%q) = %q, want %q", test.query, got, test.want)
+			}
+		})
+	}
+}
+
+func TestResolveNoMatch(t *testing.T) {
+	_, err := resolve("user", "giovanni", []string{"Mario Rossi"}, func(s string) string { return s }, nil)
+	var noMatch *noMatchError
+	if !errors.As(err, &noMatch) {
+		t.Errorf("resolve = %v, want a noMatchError", err)
+	}
+
+	if _, err := resolve("user", " ", []string{"Mario Rossi"}, func(s string) string { return s }, nil); err == nil {
+		t.Errorf("resolve of an empty name succeeded")
+	}
+}
@@ -0,0 +1,252 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/viper"
+
+	"github.com/ravvio/noty/notion"
+)
+
+const KeyStatuses = "statuses"
+
+// Name of the notion status group of closed statuses
+const closedStatusGroup = "Complete"
+
+// Roles of statuses, telling commands how to treat their tasks
+const (
+	// Tasks being worked on by their assignee
+	RoleInProgress = "in_progress"
+	// Tasks completed by their assignee, waiting for their reviewer
+	RoleReview = "review"
+	// Completed tasks
+	RoleDone = "done"
+	// Tasks closed without being completed
+	RoleNotDone = "not_done"
+)
+
+var statusRoles = []string{RoleInProgress, RoleReview, RoleDone, RoleNotDone}
+
+// Status describes a value of the status property of tasks.
+type Status struct {
+	Name string `mapstructure:"name"`
+	// Code is the shorthand used in flags, e.g. NS
+	Code  string `mapstructure:"code"`
+	Emote string `mapstructure:"emote"`
+	// Color is a notion color name, an ANSI color number or a hex color
+	Color string `mapstructure:"color"`
+	// Open statuses are the ones of tasks still to be completed
+	Open bool `mapstructure:"open"`
+	// Role is one of the status roles, if any
+	Role string `mapstructure:"role"`
+}
+
+var defaultStatuses = []Status{
+	{Name: notion.StatusNotStarted, Code: "NS", Emote: "❄️", Color: "gray", Open: true},
+	{Name: notion.StatusInProgress, Code: "P", Emote: "🚀", Color: "blue", Open: true, Role: RoleInProgress},
+	{Name: notion.StatusToBeTested, Code: "TBT", Emote: "💣", Color: "yellow", Open: true, Role: RoleReview},
+	{Name: notion.StatusInTesting, Code: "T", Emote: "💥", Color: "orange", Open: true, Role: RoleReview},
+	{Name: notion.StatusDone, Code: "D", Emote: "✅", Color: "green", Open: false, Role: RoleDone},
+	{Name: notion.StatusNotDone, Code: "ND", Emote: "❌", Color: "red", Open: false, Role: RoleNotDone},
+}
+
+// Statuses returns the configured statuses. Statuses configured without any
+// role get the roles of the default statuses with the same name.
+func Statuses() []Status {
+	statuses := make([]Status, 0)
+	if err := viper.UnmarshalKey(KeyStatuses, &statuses); err != nil || len(statuses) == 0 {
+		return defaultStatuses
+	}
+	if !slices.ContainsFunc(statuses, func(s Status) bool { return s.Role != "" }) {
+		for i, status := range statuses {
+			j := slices.IndexFunc(defaultStatuses, func(s Status) bool { return s.Name == status.Name })
+			if j >= 0 {
+				statuses[i].Role = defaultStatuses[j].Role
+			}
+		}
+	}
+	return statuses
+}
+
+func statusesValue(statuses []Status) []any {
+	values := make([]any, 0, len(statuses))
+	for _, status := range statuses {
+		value := map[string]any{
+			"name":  status.Name,
+			"code":  status.Code,
+			"emote": status.Emote,
+			"color": status.Color,
+			"open":  status.Open,
+		}
+		if status.Role != "" {
+			value["role"] = status.Role
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// FindStatus returns the status with the given name.
+func FindStatus(name string) (Status, bool) {
+	for _, status := range Statuses() {
+		if strings.EqualFold(status.Name, name) {
+			return status, true
+		}
+	}
+	return Status{}, false
+}
+
+// StatusCodes returns the shorthand codes of all statuses.
+func StatusCodes() []string {
+	codes := make([]string, 0)
+	for _, status := range Statuses() {
+		codes = append(codes, status.Code)
+	}
+	return codes
+}
+
+// ParseStatusCodes converts status shorthand codes to status names.
+func ParseStatusCodes(codes []string) ([]string, error) {
+	statuses := Statuses()
+	names := make([]string, 0, len(codes))
+	for _, code := range codes {
+		i := slices.IndexFunc(statuses, func(s Status) bool { return strings.EqualFold(s.Code, code) })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown status '%s', valid values are %v", code, StatusCodes())
+		}
+		names = append(names, statuses[i].Name)
+	}
+	return names, nil
+}
+
+// OpenStatuses returns the names of the statuses of tasks still to be
+// completed.
+func OpenStatuses() []string {
+	names := make([]string, 0)
+	for _, status := range Statuses() {
+		if status.Open {
+			names = append(names, status.Name)
+		}
+	}
+	return names
+}
+
+// IsOpenStatus reports whether name is the status of a task still to be
+// completed, unknown statuses are considered open.
+func IsOpenStatus(name string) bool {
+	status, ok := FindStatus(name)
+	return !ok || status.Open
+}
+
+// StatusesWithRole returns the names of the statuses with the given role.
+func StatusesWithRole(role string) []string {
+	names := make([]string, 0)
+	for _, status := range Statuses() {
+		if status.Role == role {
+			names = append(names, status.Name)
+		}
+	}
+	return names
+}
+
+// HasStatusRole reports whether name is a status with the given role.
+func HasStatusRole(name string, role string) bool {
+	status, ok := FindStatus(name)
+	return ok && status.Role == role
+}
+
+// StatusColor returns the color of a status, if any.
+func StatusColor(name string) string {
+	status, _ := FindStatus(name)
+	return status.Color
+}
+
+// StatusesFromOptions builds the statuses from the options of the status
+// property of the tasks database. Codes, emotes and colors of already
+// configured statuses are kept.
+func StatusesFromOptions(options []notion.StatusOption) []Status {
+	statuses := make([]Status, 0, len(options))
+	kept := make([]bool, 0, len(options))
+	codes := make([]string, 0, len(options))
+	for _, option := range options {
+		status, ok := FindStatus(option.Name)
+		if !ok {
+			status = Status{
+				Name:  option.Name,
+				Color: option.Color,
+			}
+		}
+		status.Name = option.Name
+		status.Open = option.Group != closedStatusGroup
+		if status.Color == "" {
+			status.Color = option.Color
+		}
+
+		// Codes of configured statuses are reserved before new ones are made
+		keep := status.Code != "" && !slices.Contains(codes, status.Code)
+		if keep {
+			codes = append(codes, status.Code)
+		}
+		kept = append(kept, keep)
+		statuses = append(statuses, status)
+	}
+	for i := range statuses {
+		if !kept[i] {
+			statuses[i].Code = statusCode(statuses[i].Name, codes)
+			codes = append(codes, statuses[i].Code)
+		}
+	}
+	return statuses
+}
+
+// statusCode builds a shorthand code from the initials of the words of
+// name, adding letters until it differs from the taken codes.
+func statusCode(name string, taken []string) string {
+	words := strings.Fields(name)
+	code := ""
+	for _, word := range words {
+		code += string(unicode.ToUpper([]rune(word)[0]))
+	}
+
+	rest := []rune(strings.ToUpper(strings.Join(words, "")))
+	for i := 1; slices.Contains(taken, code) || code == ""; i++ {
+		if i < len(rest) {
+			code += string(rest[i])
+		} else {
+			code += fmt.Sprintf("%d", i)
+		}
+	}
+	return code
+}
+
+// validateStatuses checks that statuses have a name, a unique code and a
+// known role.
+func validateStatuses(statuses []Status) []error {
+	errs := make([]error, 0)
+	codes := make([]string, 0, len(statuses))
+	for i, status := range statuses {
+		if status.Name == "" {
+			errs = append(errs, fmt.Errorf("status %d has no name", i+1))
+		}
+		code := strings.ToUpper(status.Code)
+		if code == "" {
+			errs = append(errs, fmt.Errorf("status '%s' has no code", status.Name))
+		} else if slices.Contains(codes, code) {
+			errs = append(errs, fmt.Errorf("status code '%s' is used more than once", status.Code))
+		}
+		codes = append(codes, code)
+		if status.Role != "" && !slices.Contains(statusRoles, status.Role) {
+			errs = append(errs, fmt.Errorf("status '%s' has unknown role '%s', valid values are %v", status.Name, status.Role, statusRoles))
+		}
+	}
+	return errs
+}
+
+// SaveStatuses writes statuses in the configuration file, returns the path
+// of the file.
+func SaveStatuses(statuses []Status) (string, error) {
+	return writeInFile(KeyStatuses, statusesValue(statuses))
+}
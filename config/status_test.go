@@ -0,0 +1,148 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ravvio/noty/notion"
+)
+
+// setStatuses configures statuses for the duration of a test.
+func setStatuses(t *testing.T, statuses []Status) {
+	t.Helper()
+	viper.Set(KeyStatuses, statusesValue(statuses))
+	t.Cleanup(func() { viper.Set(KeyStatuses, nil) })
+}
+
+func TestStatusesFromOptions(t *testing.T) {
+	setStatuses(t, []Status{
+		{Name: "Not Started", Code: "NS", Emote: "❄️", Color: "gray", Open: true},
+		{Name: "Done", Code: "D", Emote: "✅", Color: "green", Open: true, Role: RoleDone},
+	})
+
+	got := StatusesFromOptions([]notion.StatusOption{
+		{Name: "Not Started", Color: "default", Group: "To-do"},
+		{Name: "Doing", Color: "blue", Group: "In progress"},
+		{Name: "Don't", Color: "red", Group: closedStatusGroup},
+		{Name: "Done", Color: "pink", Group: closedStatusGroup},
+	})
+	want := []Status{
+		// Configured statuses keep code, emote, color and role
+		{Name: "Not Started", Code: "NS", Emote: "❄️", Color: "gray", Open: true},
+		// New statuses get codes from their initials, made unique
+		{Name: "Doing", Code: "DO", Color: "blue", Open: true},
+		{Name: "Don't", Code: "DON", Color: "red", Open: false},
+		// Openness follows the group of the option
+		{Name: "Done", Code: "D", Emote: "✅", Color: "green", Open: false, Role: RoleDone},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StatusesFromOptions() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestValidateStatuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []Status
+		errs     []string
+	}{
+		{
+			name:     "defaults",
+			statuses: defaultStatuses,
+		},
+		{
+			name:     "missing name and code",
+			statuses: []Status{{Code: "A"}, {Name: "B"}},
+			errs:     []string{"status 1 has no name", "status 'B' has no code"},
+		},
+		{
+			name:     "codes differing only in case",
+			statuses: []Status{{Name: "A", Code: "x"}, {Name: "B", Code: "X"}},
+			errs:     []string{"status code 'X' is used more than once"},
+		},
+		{
+			name:     "unknown role",
+			statuses: []Status{{Name: "A", Code: "A", Role: "finished"}},
+			errs:     []string{"status 'A' has unknown role 'finished'"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := validateStatuses(test.statuses)
+			if len(errs) != len(test.errs) {
+				t.Fatalf("validateStatuses() = %v, want %d errors", errs, len(test.errs))
+			}
+			for i, err := range errs {
+				if !strings.Contains(err.Error(), test.errs[i]) {
+					t.Errorf("error %q, want %q", err, test.errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStatusRoles(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []Status
+		role     string
+		want     []string
+		status   string
+		hasRole  bool
+	}{
+		{
+			name:    "defaults",
+			role:    RoleReview,
+			want:    []string{"To Be Tested", "In Testing"},
+			status:  "In Testing",
+			hasRole: true,
+		},
+		{
+			name: "configured roles",
+			statuses: []Status{
+				{Name: "Open", Code: "O", Open: true, Role: RoleInProgress},
+				{Name: "Closed", Code: "C", Role: RoleDone},
+			},
+			role:    RoleDone,
+			want:    []string{"Closed"},
+			status:  "Open",
+			hasRole: false,
+		},
+		{
+			// Without any configured role, default statuses keep theirs
+			name: "roles from defaults",
+			statuses: []Status{
+				{Name: "In Progress", Code: "WIP", Open: true},
+				{Name: "Doing", Code: "DO", Open: true},
+			},
+			role:    RoleInProgress,
+			want:    []string{"In Progress"},
+			status:  "Doing",
+			hasRole: false,
+		},
+		{
+			// Names match exactly, not as substrings
+			name:    "exact names",
+			role:    RoleDone,
+			want:    []string{"Done"},
+			status:  "Not Done",
+			hasRole: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.statuses != nil {
+				setStatuses(t, test.statuses)
+			}
+			if got := StatusesWithRole(test.role); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("StatusesWithRole(%q) = %v, want %v", test.role, got, test.want)
+			}
+			if got := HasStatusRole(test.status, test.role); got != test.hasRole {
+				t.Errorf("HasStatusRole(%q, %q) = %t, want %t", test.status, test.role, got, test.hasRole)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/ravvio/noty/utils"
+)
+
+// Keys specific to a single user, not exported
+var personalKeys = []string{KeyMe}
+
+// Keys of values fetched from notion, refreshed instead of shared
+var cachedKeys = []string{KeyUsers, KeyProjects, KeyCommissions}
+
+// Export writes the effective configuration as YAML to w, leaving out
+// personal and cached values.
+func Export(w io.Writer) error {
+	exported := viper.New()
+	for key, value := range viper.AllSettings() {
+		if !slices.Contains(personalKeys, key) && !slices.Contains(cachedKeys, key) {
+			exported.Set(key, value)
+		}
+	}
+	exported.SetConfigType("yaml")
+	return exported.WriteConfigTo(w)
+}
+
+// Import merges the values of the YAML configuration file at path into the
+// configuration file, leaving values from other sources out of it. Values
+// are validated as by 'config set' and nothing is written if any is invalid
+// or read-only, statuses excepted. Returns the path of the written file.
+func Import(path string) (string, error) {
+	imported := viper.New()
+	imported.SetConfigFile(path)
+	imported.SetConfigType("yaml")
+	if err := imported.ReadInConfig(); err != nil {
+		return "", err
+	}
+
+	values := make(map[string]any)
+	errs := make([]error, 0)
+	set := func(key string, value any) {
+		parsed, err := ParseValue(key, stringValue(value))
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		values[key] = parsed
+	}
+	settings := imported.AllSettings()
+	keys := utils.MapKeys(settings)
+	slices.Sort(keys)
+	for _, key := range keys {
+		value := settings[key]
+		if key == KeyStatuses {
+			statuses := make([]Status, 0)
+			if err := imported.UnmarshalKey(KeyStatuses, &statuses); err != nil {
+				errs = append(errs, fmt.Errorf("invalid value for '%s': %s", KeyStatuses, err))
+			} else if statusErrs := validateStatuses(statuses); len(statusErrs) > 0 {
+				errs = append(errs, statusErrs...)
+			} else {
+				values[key] = value
+			}
+			continue
+		}
+
+		info, err := LookupKey(key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !info.Map {
+			// Optional databases are left empty when not used
+			if stringValue(value) != "" || !strings.HasSuffix(key, "_database_id") {
+				set(key, value)
+			}
+			continue
+		}
+		subs, ok := value.(map[string]any)
+		if !ok {
+			errs = append(errs, fmt.Errorf("configuration key '%s' is a map", key))
+			continue
+		}
+		names := utils.MapKeys(subs)
+		slices.Sort(names)
+		for _, sub := range names {
+			set(key+"."+sub, subs[sub])
+		}
+	}
+	if len(errs) > 0 {
+		return "", fmt.Errorf("invalid configuration in %s:\n%w", path, errors.Join(errs...))
+	}
+	return mergeInFile(values)
+}
@@ -1,28 +1,46 @@
 package config
 
 import (
-	"strings"
+	"context"
+	"fmt"
+	"maps"
 
 	"github.com/ravvio/noty/notion"
-	"github.com/ravvio/noty/ui"
 )
 
-func ParseUsers(usernames []string) []notion.NotionUser {
-	userList := Users()
-	var users = make([]notion.NotionUser, 0)
-	for _, uf := range usernames {
-		var found *notion.NotionUser = nil
-		for _, u := range userList {
-			if strings.Contains(strings.ToLower(u.Name), strings.ToLower(uf)) {
-				found = &u
-				break
-			}
+// ParseUser returns the user best matching name, see ParseUsers.
+func ParseUser(ctx context.Context, name string) (notion.NotionUser, error) {
+	aliases := maps.Clone(UserAliases())
+	if name == "me" {
+		if Me() == "" {
+			return notion.NotionUser{}, fmt.Errorf("no user configured for 'me', set '%s' in the configuration", KeyMe)
 		}
-		if found == nil {
-			ui.PrintlnfWarn("no user found for '%s'", uf)
-		} else {
-			users = append(users, *found)
+		aliases["me"] = Me()
+	}
+	return resolveRefreshing(
+		ctx,
+		"user",
+		name,
+		Users,
+		func(u notion.NotionUser) string { return u.Name },
+		aliases,
+	)
+}
+
+// ParseUsers returns the users best matching usernames. Exact matches are
+// preferred over prefixes, prefixes of a word of the name, substrings and
+// names with typos, in this order. "me" and the configured aliases are
+// expanded to the name they refer to. If a name matches no user the cached
+// users are refreshed and the name is matched again. Fails if a name matches
+// no user or more than one user equally well.
+func ParseUsers(ctx context.Context, usernames []string) ([]notion.NotionUser, error) {
+	users := make([]notion.NotionUser, 0, len(usernames))
+	for _, name := range usernames {
+		user, err := ParseUser(ctx, name)
+		if err != nil {
+			return nil, err
 		}
+		users = append(users, user)
 	}
-	return users
+	return users, nil
 }
@@ -0,0 +1,128 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ravvio/easycli-ui/etable"
+)
+
+// Formats, chosen by the extension of the exported file
+const (
+	FormatCSV      = "csv"
+	FormatXLSX     = "xlsx"
+	FormatJSON     = "json"
+	FormatMarkdown = "md"
+)
+
+// Table is a table to export, its name is used for sheets, sections and
+// file names. Cells are exported as shown in the terminal, without styles
+// and without truncating long values.
+type Table struct {
+	Name    string
+	Columns []etable.TableColumn
+	Rows    []etable.TableRow
+
+	// Kinds of the values of columns by title, the other columns hold
+	// values of kind Others
+	Kinds  map[string]Kind
+	Others Kind
+}
+
+// data is the content of a table as shown in the terminal.
+type data struct {
+	name   string
+	header []string
+	kinds  []Kind
+	rows   [][]string
+}
+
+// Format returns the format of the file at path, CSV for unknown extensions.
+func Format(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xlsx":
+		return FormatXLSX
+	case ".json":
+		return FormatJSON
+	case ".md":
+		return FormatMarkdown
+	}
+	return FormatCSV
+}
+
+// Write exports tables to path in the format given by its extension, with
+// values typed by the kinds of their columns, dates and datetimes parsed
+// with the given layouts. Spreadsheets have a
+// sheet per table, JSON and markdown files a section per table. CSV files
+// hold a single table, the others are written next to path with their name
+// as suffix. Returns the paths of the written files.
+func Write(path string, tables []Table, layouts Layouts) ([]string, error) {
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no table to export")
+	}
+
+	contents := make([]data, 0, len(tables))
+	for _, table := range tables {
+		content, err := read(table)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, content)
+	}
+
+	switch Format(path) {
+	case FormatXLSX:
+		return []string{path}, writeXLSX(path, contents, layouts)
+	case FormatJSON:
+		return []string{path}, writeJSON(path, contents, layouts)
+	case FormatMarkdown:
+		return []string{path}, writeMarkdown(path, contents)
+	}
+	return writeCSV(path, contents)
+}
+
+// read returns the header and rows of a table.
+func read(table Table) (data, error) {
+	columns := make([]etable.TableColumn, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		columns = append(columns, column.WithMaxWidth(-1))
+	}
+	header, rows, err := Rows(etable.NewTable(columns).WithRows(table.Rows))
+	if err != nil {
+		return data{}, err
+	}
+	kinds := make([]Kind, 0, len(header))
+	for _, title := range header {
+		kind, ok := table.Kinds[title]
+		if !ok {
+			kind = table.Others
+		}
+		kinds = append(kinds, kind)
+	}
+	return data{
+		name:   table.Name,
+		header: header,
+		kinds:  kinds,
+		rows:   rows,
+	}, nil
+}
+
+// Rows returns the column titles and the rows of table, with cells as shown
+// in the terminal without styles.
+func Rows(table *etable.Table) ([]string, [][]string, error) {
+	var buf bytes.Buffer
+	if err := table.ExportCSV(&buf); err != nil {
+		return nil, nil, err
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, nil
+	}
+	return records[0], records[1:], nil
+}
@@ -0,0 +1,40 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ravvio/easycli-ui/etable"
+)
+
+func TestReadUntruncated(t *testing.T) {
+	name := strings.Repeat("a very long task name ", 5)
+	table := Table{
+		Name: "Tasks",
+		Columns: []etable.TableColumn{
+			etable.NewTableColumn("name", "Name").WithMaxWidth(10),
+			etable.NewTableColumn("estimate", "Estimate"),
+		},
+		Rows: []etable.TableRow{
+			{"name": name, "estimate": "3.5 h"},
+		},
+		Kinds: map[string]Kind{"Estimate": KindHours},
+	}
+
+	data, err := read(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(data.header, ","); got != "Name,Estimate" {
+		t.Errorf("header = %q, want %q", got, "Name,Estimate")
+	}
+	if len(data.rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(data.rows))
+	}
+	if data.rows[0][0] != name {
+		t.Errorf("name = %q, want %q", data.rows[0][0], name)
+	}
+	if data.kinds[1] != KindHours {
+		t.Errorf("estimate kind = %d, want %d", data.kinds[1], KindHours)
+	}
+}
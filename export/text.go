@@ -0,0 +1,89 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ravvio/noty/utils"
+)
+
+// writeCSV writes the first table to path and each other one next to it.
+func writeCSV(path string, contents []data) ([]string, error) {
+	paths := make([]string, 0, len(contents))
+	for i, content := range contents {
+		p := path
+		if i > 0 {
+			p = utils.SuffixPath(path, "-"+strings.ToLower(content.name))
+		}
+		if err := utils.WriteFileAtomic(p, func(w io.Writer) error {
+			writer := csv.NewWriter(w)
+			if err := writer.Write(content.header); err != nil {
+				return err
+			}
+			return writer.WriteAll(content.rows)
+		}); err != nil {
+			return paths, err
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+// writeJSON writes an object with a list of records per table, keyed by the
+// name of the table. Records are keyed by column title.
+func writeJSON(path string, contents []data, layouts Layouts) error {
+	tables := make(map[string][]map[string]any, len(contents))
+	for _, content := range contents {
+		records := make([]map[string]any, 0, len(content.rows))
+		for _, row := range content.rows {
+			record := make(map[string]any, len(row))
+			for i, cell := range row {
+				record[content.header[i]] = parseValue(cell, content.kinds[i], layouts).json()
+			}
+			records = append(records, record)
+		}
+		tables[strings.ToLower(content.name)] = records
+	}
+
+	return utils.WriteFileAtomic(path, func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(tables)
+	})
+}
+
+// writeMarkdown writes a section with a markdown table per table.
+func writeMarkdown(path string, contents []data) error {
+	var b strings.Builder
+	for i, content := range contents {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n\n", content.name)
+		writeMarkdownRow(&b, content.header)
+		separator := make([]string, len(content.header))
+		for j := range separator {
+			separator[j] = "---"
+		}
+		writeMarkdownRow(&b, separator)
+		for _, row := range content.rows {
+			writeMarkdownRow(&b, row)
+		}
+	}
+
+	return utils.WriteFileAtomic(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, b.String())
+		return err
+	})
+}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	escaped := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		escaped = append(escaped, strings.ReplaceAll(cell, "|", `\|`))
+	}
+	fmt.Fprintf(b, "| %s |\n", strings.Join(escaped, " | "))
+}
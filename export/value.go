@@ -0,0 +1,79 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts of the dates and datetimes shown in tables.
+type Layouts struct {
+	Date     string
+	Datetime string
+}
+
+// Kind is the type of the values of a column.
+type Kind int
+
+// Kinds of values
+const (
+	KindString Kind = iota
+	KindInt
+	KindFloat
+	// Numbers of hours, shown as 3.5 h
+	KindHours
+	// Ratios, shown as 80%
+	KindPercent
+	KindDate
+	KindDatetime
+)
+
+// value is a typed table cell.
+type value struct {
+	kind   Kind
+	text   string
+	number float64
+	time   time.Time
+}
+
+// parseValue converts a cell formatted for the terminal to a value of the
+// kind of its column. Cells that do not match their kind, e.g. the - of
+// missing values, are kept as strings.
+func parseValue(s string, kind Kind, layouts Layouts) value {
+	v := value{kind: kind, text: s}
+	var err error
+	switch kind {
+	case KindInt, KindFloat:
+		v.number, err = strconv.ParseFloat(s, 64)
+	case KindHours:
+		v.number, err = strconv.ParseFloat(strings.TrimSuffix(s, " h"), 64)
+	case KindPercent:
+		v.number, err = strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		v.number /= 100
+	case KindDate:
+		v.time, err = time.ParseInLocation(layouts.Date, s, time.Local)
+	case KindDatetime:
+		v.time, err = time.ParseInLocation(layouts.Datetime, s, time.Local)
+	}
+	if err != nil {
+		return value{kind: KindString, text: s}
+	}
+	return v
+}
+
+// json returns the value as stored in JSON files, numbers as numbers, times
+// in RFC 3339 and empty cells as null.
+func (v value) json() any {
+	switch v.kind {
+	case KindInt, KindFloat, KindHours, KindPercent:
+		return v.number
+	case KindDate:
+		return v.time.Format(time.DateOnly)
+	case KindDatetime:
+		return v.time.Format(time.RFC3339)
+	}
+	if v.text == "" {
+		return nil
+	}
+	return v.text
+}
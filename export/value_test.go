@@ -0,0 +1,48 @@
+package export
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseValue(t *testing.T) {
+	layouts := Layouts{Date: "02/01/2006", Datetime: "02/01/2006 15:04"}
+	tests := []struct {
+		s      string
+		kind   Kind
+		want   Kind
+		number float64
+		time   time.Time
+	}{
+		{"Task", KindString, KindString, 0, time.Time{}},
+		{"12", KindInt, KindInt, 12, time.Time{}},
+		{"2.25", KindFloat, KindFloat, 2.25, time.Time{}},
+		{"3.5 h", KindHours, KindHours, 3.5, time.Time{}},
+		{"80%", KindPercent, KindPercent, 0.8, time.Time{}},
+		{"23/11/2013", KindDate, KindDate, 0, time.Date(2013, time.November, 23, 0, 0, 0, 0, time.Local)},
+		{"23/11/2013 21:37", KindDatetime, KindDatetime, 0, time.Date(2013, time.November, 23, 21, 37, 0, 0, time.Local)},
+		{"-", KindInt, KindString, 0, time.Time{}},
+		{"", KindFloat, KindString, 0, time.Time{}},
+		{"3.5", KindHours, KindHours, 3.5, time.Time{}},
+		{"three h", KindHours, KindString, 0, time.Time{}},
+		{"-", KindPercent, KindString, 0, time.Time{}},
+		{"2013-11-23", KindDate, KindString, 0, time.Time{}},
+		{"23/11/2013", KindDatetime, KindString, 0, time.Time{}},
+	}
+	for _, test := range tests {
+		v := parseValue(test.s, test.kind, layouts)
+		if v.kind != test.want {
+			t.Errorf("parseValue(%q, %d) kind = %d, want %d", test.s, test.kind, v.kind, test.want)
+			continue
+		}
+		if v.text != test.s {
+			t.Errorf("parseValue(%q, %d) text = %q, want %q", test.s, test.kind, v.text, test.s)
+		}
+		if v.number != test.number {
+			t.Errorf("parseValue(%q, %d) number = %v, want %v", test.s, test.kind, v.number, test.number)
+		}
+		if !v.time.Equal(test.time) {
+			t.Errorf("parseValue(%q, %d) time = %v, want %v", test.s, test.kind, v.time, test.time)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"io"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/ravvio/noty/utils"
+)
+
+// Number formats of typed cells
+var numberFormats = map[Kind]string{
+	KindInt:      "0",
+	KindFloat:    "0.00",
+	KindHours:    `0.0 "h"`,
+	KindPercent:  "0%",
+	KindDate:     "yyyy-mm-dd",
+	KindDatetime: "yyyy-mm-dd hh:mm",
+}
+
+// Bounds of the width of columns, in characters
+const (
+	minColumnWidth = 6
+	maxColumnWidth = 60
+)
+
+// writeXLSX writes a spreadsheet with a sheet per table, with a styled and
+// frozen header and typed cells.
+func writeXLSX(path string, contents []data, layouts Layouts) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"2F5597"}},
+	})
+	if err != nil {
+		return err
+	}
+	styles := make(map[Kind]int, len(numberFormats))
+	for kind, format := range numberFormats {
+		if styles[kind], err = f.NewStyle(&excelize.Style{CustomNumFmt: &format}); err != nil {
+			return err
+		}
+	}
+
+	for i, content := range contents {
+		sheet := content.name
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+				return err
+			}
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			return err
+		}
+
+		widths := make([]int, len(content.header))
+		for col, title := range content.header {
+			cell, err := excelize.CoordinatesToCellName(col+1, 1)
+			if err != nil {
+				return err
+			}
+			if err := f.SetCellValue(sheet, cell, title); err != nil {
+				return err
+			}
+			if err := f.SetCellStyle(sheet, cell, cell, headerStyle); err != nil {
+				return err
+			}
+			widths[col] = utf8.RuneCountInString(title)
+		}
+
+		for row, cells := range content.rows {
+			for col, text := range cells {
+				if text == "" {
+					continue
+				}
+				cell, err := excelize.CoordinatesToCellName(col+1, row+2)
+				if err != nil {
+					return err
+				}
+
+				v := parseValue(text, content.kinds[col], layouts)
+				switch v.kind {
+				case KindString:
+					err = f.SetCellStr(sheet, cell, v.text)
+				case KindDate, KindDatetime:
+					err = f.SetCellValue(sheet, cell, v.time)
+				default:
+					err = f.SetCellFloat(sheet, cell, v.number, -1, 64)
+				}
+				if err != nil {
+					return err
+				}
+				if style, ok := styles[v.kind]; ok {
+					if err := f.SetCellStyle(sheet, cell, cell, style); err != nil {
+						return err
+					}
+				}
+				widths[col] = max(widths[col], utf8.RuneCountInString(text))
+			}
+		}
+
+		for col, width := range widths {
+			name, err := excelize.ColumnNumberToName(col + 1)
+			if err != nil {
+				return err
+			}
+			width = min(max(width+2, minColumnWidth), maxColumnWidth)
+			if err := f.SetColWidth(sheet, name, name, float64(width)); err != nil {
+				return err
+			}
+		}
+
+		if err := f.SetPanes(sheet, &excelize.Panes{
+			Freeze:      true,
+			YSplit:      1,
+			TopLeftCell: "A2",
+			ActivePane:  "bottomLeft",
+		}); err != nil {
+			return err
+		}
+		if len(content.header) > 0 {
+			last, err := excelize.CoordinatesToCellName(len(content.header), len(content.rows)+1)
+			if err != nil {
+				return err
+			}
+			if err := f.AutoFilter(sheet, "A1:"+last, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return utils.WriteFileAtomic(path, func(w io.Writer) error {
+		_, err := f.WriteTo(w)
+		return err
+	})
+}
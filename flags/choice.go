@@ -55,10 +55,12 @@ func StringChoice(choices []string, defaultValue string) *choiceValue[string] {
 	}
 }
 
+// StringChoiceOrDate accepts one of choices or a date, layout is called when
+// the flag is set so that it can depend on the loaded configuration.
 func StringChoiceOrDate(
 	choices []string,
 	defaultValue string,
-	layout string,
+	layout func() string,
 ) *choiceValue[string] {
 	return &choiceValue[string]{
 		value: defaultValue,
@@ -66,10 +68,10 @@ func StringChoiceOrDate(
 			if slices.Contains(choices, s) {
 				return nil
 			}
-			if _, err := time.Parse(layout, s); err != nil {
+			if _, err := time.Parse(layout(), s); err == nil {
 				return nil
 			}
-			return fmt.Errorf("must be one of %v or date with layout %s", choices, layout)
+			return fmt.Errorf("must be one of %v or date with layout %s", choices, layout())
 		},
 		convert:   func(s string) (string, error) { return s, nil },
 		toString:  func(s string) string { return s },
@@ -0,0 +1,295 @@
+package grouping
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ravvio/easycli-ui/etable"
+)
+
+// Value shown in the rows of subtotals and of the grand total
+const TotalName = "Total"
+
+// Value shown for items with no value of a dimension
+const emptyName = "-"
+
+// Dimension is a property items are grouped by.
+type Dimension[T any] struct {
+	// Key of the column of the dimension
+	Key   string
+	Title string
+	Value func(item T) string
+
+	// Compare orders the values of groups, names with numbers compared by
+	// value if not set
+	Compare func(a string, b string) int
+}
+
+// compare orders a and b, empty values last.
+func (dim Dimension[T]) compare(a string, b string) int {
+	aEmpty := a == "" || a == emptyName
+	bEmpty := b == "" || b == emptyName
+	switch {
+	case aEmpty && bEmpty:
+		return 0
+	case aEmpty:
+		return 1
+	case bEmpty:
+		return -1
+	case dim.Compare != nil:
+		return dim.Compare(a, b)
+	}
+	return CompareNatural(a, b)
+}
+
+// Group holds the items with the same value of a dimension, and their groups
+// by the next dimension.
+type Group[T any] struct {
+	Value  string
+	Items  []T
+	Groups []*Group[T]
+}
+
+// By groups items by each of dims in turn. Groups are sorted by value, the
+// one of items with no value last.
+func By[T any](items []T, dims []Dimension[T]) *Group[T] {
+	root := &Group[T]{Items: items}
+	root.split(dims)
+	return root
+}
+
+func (g *Group[T]) split(dims []Dimension[T]) {
+	if len(dims) == 0 {
+		return
+	}
+
+	groups := make(map[string]*Group[T])
+	for _, item := range g.Items {
+		value := dims[0].Value(item)
+		group, ok := groups[value]
+		if !ok {
+			group = &Group[T]{Value: value}
+			groups[value] = group
+			g.Groups = append(g.Groups, group)
+		}
+		group.Items = append(group.Items, item)
+	}
+	slices.SortFunc(g.Groups, func(a, b *Group[T]) int {
+		return dims[0].compare(a.Value, b.Value)
+	})
+
+	for _, group := range g.Groups {
+		group.split(dims[1:])
+	}
+}
+
+// CompareNatural compares strings ignoring case, with numbers compared by
+// value so that Sprint 9 comes before Sprint 10.
+func CompareNatural(a string, b string) int {
+	a = strings.ToLower(a)
+	b = strings.ToLower(b)
+	for a != "" && b != "" {
+		aNumber, aRest := leadingNumber(a)
+		bNumber, bRest := leadingNumber(b)
+		if aNumber != "" && bNumber != "" {
+			if c := cmp.Compare(len(aNumber), len(bNumber)); c != 0 {
+				return c
+			}
+			if c := strings.Compare(aNumber, bNumber); c != 0 {
+				return c
+			}
+			a, b = aRest, bRest
+			continue
+		}
+
+		aRune, aSize := utf8.DecodeRuneInString(a)
+		bRune, bSize := utf8.DecodeRuneInString(b)
+		if aRune != bRune {
+			return cmp.Compare(aRune, bRune)
+		}
+		a, b = a[aSize:], b[bSize:]
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// leadingNumber splits s after its leading digits, returned without leading
+// zeros.
+func leadingNumber(s string) (string, string) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return "", s
+	}
+	number := strings.TrimLeft(s[:i], "0")
+	if number == "" {
+		number = "0"
+	}
+	return number, s[i:]
+}
+
+// CompareOrder returns a comparison following order, values not in order
+// come after the others.
+func CompareOrder(order []string) func(a string, b string) int {
+	return func(a string, b string) int {
+		i := slices.Index(order, a)
+		j := slices.Index(order, b)
+		switch {
+		case i < 0 && j < 0:
+			return CompareNatural(a, b)
+		case i < 0:
+			return 1
+		case j < 0:
+			return -1
+		}
+		return cmp.Compare(i, j)
+	}
+}
+
+// Columns returns the columns of dims, followed by the given aggregate
+// columns.
+func Columns[T any](dims []Dimension[T], aggregates ...etable.TableColumn) []etable.TableColumn {
+	columns := make([]etable.TableColumn, 0, len(dims)+len(aggregates))
+	for _, dim := range dims {
+		columns = append(columns, etable.NewTableColumn(dim.Key, dim.Title).WithStyleFunc(totalStyle))
+	}
+	return append(columns, aggregates...)
+}
+
+func totalStyle(style lipgloss.Style, value string) lipgloss.Style {
+	if value == TotalName {
+		return style.Bold(true)
+	}
+	return style
+}
+
+// Rows returns a row for each group of the last dimension, with the values
+// of its enclosing groups. The groups of the other dimensions are followed by
+// a subtotal row and the last row is the grand total. aggregate fills the
+// aggregate columns of a row from the items of its group.
+func Rows[T any](
+	root *Group[T],
+	dims []Dimension[T],
+	aggregate func(items []T) etable.TableRow,
+) []etable.TableRow {
+	rows := make([]etable.TableRow, 0)
+	row := func(path []string, items []T) etable.TableRow {
+		r := aggregate(items)
+		for i, value := range path {
+			if value == "" {
+				value = emptyName
+			}
+			r[dims[i].Key] = value
+		}
+		return r
+	}
+
+	var walk func(group *Group[T], path []string)
+	walk = func(group *Group[T], path []string) {
+		if len(path) == len(dims) {
+			rows = append(rows, row(path, group.Items))
+			return
+		}
+		for _, child := range group.Groups {
+			walk(child, append(slices.Clone(path), child.Value))
+		}
+		if len(path) > 0 {
+			rows = append(rows, row(append(slices.Clone(path), TotalName), group.Items))
+		}
+	}
+	walk(root, nil)
+
+	return append(rows, row([]string{TotalName}, root.Items))
+}
+
+// Stats summarizes a list of values.
+type Stats struct {
+	Count int
+	Sum   float64
+	Min   float64
+	Max   float64
+	Avg   float64
+}
+
+// NewStats returns the stats of values, all zero if there are none.
+func NewStats(values []float64) Stats {
+	stats := Stats{Count: len(values)}
+	if len(values) == 0 {
+		return stats
+	}
+	stats.Min = slices.Min(values)
+	stats.Max = slices.Max(values)
+	for _, value := range values {
+		stats.Sum += value
+	}
+	stats.Avg = stats.Sum / float64(len(values))
+	return stats
+}
+
+// Pivot returns the columns and rows of a cross tabulation of items, with a
+// row per value of rows and a column per value of cols. Each cell holds value
+// of the items with both values, a last column and a last row hold totals.
+func Pivot[T any](
+	items []T,
+	rows Dimension[T],
+	cols Dimension[T],
+	value func(items []T) string,
+) ([]etable.TableColumn, []etable.TableRow) {
+	byCol := By(items, []Dimension[T]{cols})
+	columns := []etable.TableColumn{
+		etable.NewTableColumn(rows.Key, rows.Title).WithStyleFunc(totalStyle),
+	}
+	for _, group := range byCol.Groups {
+		title := group.Value
+		if title == "" {
+			title = emptyName
+		}
+		columns = append(
+			columns,
+			etable.NewTableColumn(pivotKey(group.Value), title).WithAlignment(etable.TableAlignmentRight),
+		)
+	}
+	columns = append(
+		columns,
+		etable.NewTableColumn(pivotTotalKey, TotalName).WithAlignment(etable.TableAlignmentRight),
+	)
+
+	row := func(name string, items []T) etable.TableRow {
+		if name == "" {
+			name = emptyName
+		}
+		r := etable.TableRow{
+			rows.Key:      name,
+			pivotTotalKey: value(items),
+		}
+		cells := make(map[string][]T)
+		for _, item := range items {
+			key := pivotKey(cols.Value(item))
+			cells[key] = append(cells[key], item)
+		}
+		for _, group := range byCol.Groups {
+			r[pivotKey(group.Value)] = value(cells[pivotKey(group.Value)])
+		}
+		return r
+	}
+
+	byRow := By(items, []Dimension[T]{rows})
+	tableRows := make([]etable.TableRow, 0, len(byRow.Groups)+1)
+	for _, group := range byRow.Groups {
+		tableRows = append(tableRows, row(group.Value, group.Items))
+	}
+	tableRows = append(tableRows, row(TotalName, items))
+	return columns, tableRows
+}
+
+// Key of the column of row totals of pivot tables
+const pivotTotalKey = "pivot:total"
+
+func pivotKey(value string) string {
+	return "pivot:value:" + value
+}
@@ -0,0 +1,149 @@
+package grouping
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ravvio/easycli-ui/etable"
+)
+
+type item struct {
+	team  string
+	user  string
+	hours int
+}
+
+var (
+	byTeam = Dimension[item]{Key: "team", Title: "Team", Value: func(i item) string { return i.team }}
+	byUser = Dimension[item]{Key: "user", Title: "User", Value: func(i item) string { return i.user }}
+)
+
+var items = []item{
+	{team: "web", user: "bob", hours: 1},
+	{team: "api", user: "amy", hours: 2},
+	{team: "web", user: "amy", hours: 3},
+	{team: "", user: "carl", hours: 4},
+	{team: "web", user: "bob", hours: 5},
+}
+
+func sumHours(items []item) etable.TableRow {
+	sum := 0
+	for _, i := range items {
+		sum += i.hours
+	}
+	return etable.TableRow{"hours": fmt.Sprint(sum)}
+}
+
+func TestByRows(t *testing.T) {
+	tests := []struct {
+		name string
+		dims []Dimension[item]
+		want []etable.TableRow
+	}{
+		{
+			// Empty values are shown as - and come last
+			name: "one dimension",
+			dims: []Dimension[item]{byTeam},
+			want: []etable.TableRow{
+				{"team": "api", "hours": "2"},
+				{"team": "web", "hours": "9"},
+				{"team": "-", "hours": "4"},
+				{"team": "Total", "hours": "15"},
+			},
+		},
+		{
+			// Groups of the outer dimension are followed by subtotals
+			name: "two dimensions",
+			dims: []Dimension[item]{byTeam, byUser},
+			want: []etable.TableRow{
+				{"team": "api", "user": "amy", "hours": "2"},
+				{"team": "api", "user": "Total", "hours": "2"},
+				{"team": "web", "user": "amy", "hours": "3"},
+				{"team": "web", "user": "bob", "hours": "6"},
+				{"team": "web", "user": "Total", "hours": "9"},
+				{"team": "-", "user": "carl", "hours": "4"},
+				{"team": "-", "user": "Total", "hours": "4"},
+				{"team": "Total", "hours": "15"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Rows(By(items, test.dims), test.dims, sumHours)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Rows() =\n%v\nwant\n%v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPivot(t *testing.T) {
+	columns, rows := Pivot(items, byUser, byTeam, func(items []item) string {
+		return sumHours(items)["hours"]
+	})
+
+	var header strings.Builder
+	if err := etable.NewTable(columns).ExportCSV(&header); err != nil {
+		t.Fatal(err)
+	}
+	if want := "User,api,web,-,Total\n"; header.String() != want {
+		t.Errorf("Pivot() columns = %q, want %q", header.String(), want)
+	}
+
+	api, web, none := pivotKey("api"), pivotKey("web"), pivotKey("")
+	want := []etable.TableRow{
+		{"user": "amy", api: "2", web: "3", none: "0", pivotTotalKey: "5"},
+		{"user": "bob", api: "0", web: "6", none: "0", pivotTotalKey: "6"},
+		{"user": "carl", api: "0", web: "0", none: "4", pivotTotalKey: "4"},
+		{"user": "Total", api: "2", web: "9", none: "4", pivotTotalKey: "15"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Pivot() rows =\n%v\nwant\n%v", rows, want)
+	}
+}
+
+func TestCompareNatural(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"a", "b", -1},
+		{"B", "a", 1},
+		{"Alpha", "alpha", 0},
+		{"Sprint 9", "Sprint 10", -1},
+		{"Sprint 10", "Sprint 9", 1},
+		{"Sprint 09", "Sprint 9", 0},
+		{"2026-W02", "2026-W10", -1},
+		{"item2b", "item2a", 1},
+		{"abc", "abcd", -1},
+		{"", "a", -1},
+	}
+	for _, test := range tests {
+		if got := CompareNatural(test.a, test.b); got != test.want {
+			t.Errorf("CompareNatural(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestCompareOrder(t *testing.T) {
+	compare := CompareOrder([]string{"High", "Medium", "Low"})
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"High", "Low", -1},
+		{"Low", "Medium", 1},
+		{"Medium", "Medium", 0},
+		// Values not in order come after the others, naturally sorted
+		{"Urgent", "Low", 1},
+		{"Low", "Urgent", -1},
+		{"Later 2", "Later 10", -1},
+	}
+	for _, test := range tests {
+		if got := compare(test.a, test.b); got != test.want {
+			t.Errorf("compare(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
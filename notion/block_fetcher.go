@@ -0,0 +1,129 @@
+package notion
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+func (client *Client) NewBlockFetcher(
+	ctx context.Context,
+	blockID string,
+) Fetcher[*BlockFetcher, notionapi.Block] {
+	fetcher := &BlockFetcher{
+		client:  client,
+		blockID: blockID,
+		limit:   100,
+		cursor:  nil,
+	}
+	return NewFetcher(
+		ctx,
+		fetcher,
+		100,
+	)
+}
+
+type BlockFetcher struct {
+	client  *Client
+	blockID string
+	limit   int
+	cursor  *string
+}
+
+func (fetcher *BlockFetcher) Fetch(
+	ctx context.Context,
+) (FetchData[notionapi.Block], error) {
+	req := notionapi.Pagination{
+		PageSize: fetcher.limit,
+	}
+	if fetcher.cursor != nil {
+		req.StartCursor = notionapi.Cursor(*fetcher.cursor)
+	}
+
+	res, err := fetcher.client.client.Block.GetChildren(
+		ctx,
+		notionapi.BlockID(fetcher.blockID),
+		&req,
+	)
+	if err != nil {
+		return FetchData[notionapi.Block]{}, err
+	}
+
+	fd := FetchData[notionapi.Block]{
+		NextToken: nil,
+		Data:      res.Results,
+	}
+	if res.HasMore {
+		cursor := res.NextCursor
+		fd.NextToken = &cursor
+	}
+	return fd, nil
+}
+
+func (fetcher *BlockFetcher) RequestLimit() int {
+	return fetcher.limit
+}
+
+func (fetcher *BlockFetcher) SetRequestLimit(limit int) {
+	fetcher.limit = limit
+}
+
+func (fetcher *BlockFetcher) SetNextToken(cursor *string) {
+	fetcher.cursor = cursor
+}
+
+// ParseBlockText returns the plain text content of a block, or an empty
+// string for blocks without text.
+func ParseBlockText(b notionapi.Block) string {
+	switch block := b.(type) {
+	case *notionapi.CodeBlock:
+		return ParseRichTextList(block.Code.RichText)
+	case *notionapi.ChildPageBlock:
+		return block.ChildPage.Title
+	case *notionapi.TableRowBlock:
+		cells := make([]string, 0, len(block.TableRow.Cells))
+		for _, cell := range block.TableRow.Cells {
+			cells = append(cells, ParseRichTextList(cell))
+		}
+		return strings.Join(cells, " ")
+	}
+	return b.GetRichTextString()
+}
+
+// BlockNode is a block along with its nested children.
+type BlockNode struct {
+	Block    notionapi.Block
+	Children []BlockNode
+}
+
+// FetchBlockTree fetches all the blocks contained in a block or page,
+// descending recursively into blocks with children. Child pages and databases
+// are not descended into.
+func (client *Client) FetchBlockTree(
+	ctx context.Context,
+	blockID string,
+) ([]BlockNode, error) {
+	fetcher := client.NewBlockFetcher(ctx, blockID)
+	blocks, err := fetcher.All()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]BlockNode, 0, len(blocks))
+	for _, block := range blocks {
+		node := BlockNode{Block: block}
+		switch block.(type) {
+		case *notionapi.ChildPageBlock, *notionapi.ChildDatabaseBlock:
+		default:
+			if block.GetHasChildren() {
+				node.Children, err = client.FetchBlockTree(ctx, block.GetID().String())
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
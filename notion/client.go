@@ -1,6 +1,7 @@
 package notion
 
 import (
+	"net/http"
 	"os"
 
 	"github.com/jomei/notionapi"
@@ -12,11 +13,21 @@ var (
 
 type Client struct {
 	client *notionapi.Client
+	pages  *pageTitles
 }
 
 func NewClient() *Client {
-	client := notionapi.NewClient(notionapi.Token(token))
+	client := notionapi.NewClient(
+		notionapi.Token(token),
+		notionapi.WithHTTPClient(&http.Client{
+			Transport: limitedTransport{base: http.DefaultTransport},
+		}),
+	)
 	return &Client{
 		client: client,
+		pages: &pageTitles{
+			titles:      make(map[string]string),
+			unavailable: make(map[string]bool),
+		},
 	}
 }
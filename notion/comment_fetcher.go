@@ -0,0 +1,124 @@
+package notion
+
+import (
+	"context"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+func (client *Client) NewCommentFetcher(
+	ctx context.Context,
+	blockID string,
+) Fetcher[*CommentFetcher, Comment] {
+	fetcher := &CommentFetcher{
+		client:  client,
+		blockID: blockID,
+		limit:   100,
+		cursor:  nil,
+	}
+	return NewFetcher(
+		ctx,
+		fetcher,
+		100,
+	)
+}
+
+type Comment struct {
+	ID           string
+	DiscussionID string
+	AuthorID     string
+	Created      time.Time
+	Text         string
+}
+
+func parseComment(c notionapi.Comment) Comment {
+	return Comment{
+		ID:           c.ID.String(),
+		DiscussionID: c.DiscussionID.String(),
+		AuthorID:     c.CreatedBy.ID.String(),
+		Created:      c.CreatedTime,
+		Text:         ParseRichTextList(c.RichText),
+	}
+}
+
+type CommentFetcher struct {
+	client  *Client
+	blockID string
+	limit   int
+	cursor  *string
+}
+
+func (fetcher *CommentFetcher) Fetch(
+	ctx context.Context,
+) (FetchData[Comment], error) {
+	req := notionapi.Pagination{
+		PageSize: fetcher.limit,
+	}
+	if fetcher.cursor != nil {
+		req.StartCursor = notionapi.Cursor(*fetcher.cursor)
+	}
+
+	res, err := fetcher.client.client.Comment.Get(
+		ctx,
+		notionapi.BlockID(fetcher.blockID),
+		&req,
+	)
+	if err != nil {
+		return FetchData[Comment]{}, err
+	}
+
+	comments := make([]Comment, 0, len(res.Results))
+	for _, result := range res.Results {
+		comments = append(comments, parseComment(result))
+	}
+
+	fd := FetchData[Comment]{
+		NextToken: nil,
+		Data:      comments,
+	}
+	if res.HasMore {
+		cursor := res.NextCursor.String()
+		fd.NextToken = &cursor
+	}
+	return fd, nil
+}
+
+func (fetcher *CommentFetcher) RequestLimit() int {
+	return fetcher.limit
+}
+
+func (fetcher *CommentFetcher) SetRequestLimit(limit int) {
+	fetcher.limit = limit
+}
+
+func (fetcher *CommentFetcher) SetNextToken(cursor *string) {
+	fetcher.cursor = cursor
+}
+
+// CreateComment adds a new comment with the given text to a page.
+func (client *Client) CreateComment(
+	ctx context.Context,
+	pageID string,
+	text string,
+) (Comment, error) {
+	res, err := client.client.Comment.Create(
+		ctx,
+		&notionapi.CommentCreateRequest{
+			Parent: notionapi.Parent{
+				Type:   notionapi.ParentTypePageID,
+				PageID: notionapi.PageID(pageID),
+			},
+			RichText: []notionapi.RichText{
+				{
+					Type: notionapi.ObjectTypeText,
+					Text: &notionapi.Text{Content: text},
+				},
+			},
+		},
+	)
+	if err != nil {
+		return Comment{}, err
+	}
+	return parseComment(*res), nil
+}
@@ -0,0 +1,87 @@
+package notion
+
+import (
+	"context"
+
+	"github.com/jomei/notionapi"
+)
+
+func (client *Client) NewCommissionFetcher(
+	ctx context.Context,
+	databaseID string,
+) Fetcher[*CommissionFetcher, Commission] {
+	fetcher := &CommissionFetcher{
+		client:     client,
+		limit:      100,
+		cursor:     nil,
+		databaseID: databaseID,
+	}
+	return NewFetcher(
+		ctx,
+		fetcher,
+		100,
+	)
+}
+
+// Commission is a job billed to a client, hours entries refer to it.
+type Commission struct {
+	ID   string
+	Name string
+}
+
+type CommissionFetcher struct {
+	client     *Client
+	limit      int
+	cursor     *string
+	databaseID string
+}
+
+func (fetcher *CommissionFetcher) Fetch(
+	ctx context.Context,
+) (FetchData[Commission], error) {
+	req := notionapi.DatabaseQueryRequest{
+		PageSize: fetcher.limit,
+	}
+	if fetcher.cursor != nil {
+		req.StartCursor = notionapi.Cursor(*fetcher.cursor)
+	}
+
+	res, err := fetcher.client.client.Database.Query(
+		ctx,
+		notionapi.DatabaseID(fetcher.databaseID),
+		&req,
+	)
+	if err != nil {
+		return FetchData[Commission]{}, err
+	}
+
+	commissions := make([]Commission, 0)
+	for _, result := range res.Results {
+		commissions = append(commissions, Commission{
+			ID:   result.ID.String(),
+			Name: PageTitle(result),
+		})
+	}
+
+	fd := FetchData[Commission]{
+		NextToken: nil,
+		Data:      commissions,
+	}
+	if res.HasMore {
+		cursor := res.NextCursor.String()
+		fd.NextToken = &cursor
+	}
+	return fd, nil
+}
+
+func (fetcher *CommissionFetcher) RequestLimit() int {
+	return fetcher.limit
+}
+
+func (fetcher *CommissionFetcher) SetRequestLimit(limit int) {
+	fetcher.limit = limit
+}
+
+func (fetcher *CommissionFetcher) SetNextToken(cursor *string) {
+	fetcher.cursor = cursor
+}
@@ -0,0 +1,154 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Defaults of the concurrency settings, notion allows an average of three
+// requests per second.
+const (
+	DefaultMaxWorkers        = 3
+	DefaultRequestsPerSecond = 3.0
+)
+
+var (
+	maxWorkers = DefaultMaxWorkers
+	limiter    = newRateLimiter(DefaultRequestsPerSecond)
+)
+
+// SetConcurrency sets the maximum number of fetchers run in parallel by a
+// Group and the maximum rate of requests shared by all clients, a
+// non-positive rate disables rate limiting.
+func SetConcurrency(workers int, requestsPerSecond float64) {
+	maxWorkers = max(1, workers)
+	limiter.SetRate(requestsPerSecond)
+}
+
+// rateLimiter spaces requests evenly to keep their rate under a limit.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	l := &rateLimiter{}
+	l.SetRate(requestsPerSecond)
+	return l
+}
+
+func (l *rateLimiter) SetRate(requestsPerSecond float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if requestsPerSecond <= 0 {
+		l.interval = 0
+	} else {
+		l.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+}
+
+// Wait blocks until a request can be made or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait := time.Until(at); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
+
+// limitedTransport waits for the shared rate limiter before each request.
+type limitedTransport struct {
+	base http.RoundTripper
+}
+
+func (t limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// Group runs functions, usually fetchers, in parallel with a bounded number
+// of workers. The first error cancels the context of the group.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	errOnce sync.Once
+	err     error
+}
+
+// NewGroup returns a group running at most the configured number of
+// workers, and the context to create its fetchers with.
+func (client *Client) NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{
+		ctx:    ctx,
+		cancel: cancel,
+		sem:    make(chan struct{}, maxWorkers),
+	}, ctx
+}
+
+// Go runs f in a new worker as soon as one is available.
+func (g *Group) Go(f func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		select {
+		case g.sem <- struct{}{}:
+		case <-g.ctx.Done():
+			g.fail(g.ctx.Err())
+			return
+		}
+		defer func() { <-g.sem }()
+
+		if err := f(); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+func (g *Group) fail(err error) {
+	g.errOnce.Do(func() {
+		g.err = err
+		g.cancel()
+	})
+}
+
+// Wait waits for all functions to return, and returns the first error.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
+
+// FetchAll fetches all results of fetcher in a worker of group, storing
+// them in res, partial on error. The fetcher must be created with the
+// context of the group.
+func FetchAll[C FetcherClient[T], T any](group *Group, fetcher Fetcher[C, T], res *[]T) {
+	group.Go(func() error {
+		data, err := fetcher.All()
+		*res = data
+		return err
+	})
+}
@@ -6,6 +6,9 @@ import (
 	"fmt"
 )
 
+// ErrNoData is returned by NextOne when no item matches.
+var ErrNoData = errors.New("no data")
+
 type FetchData[T any] struct {
 	Data      []T
 	NextToken *string
@@ -24,8 +27,15 @@ type Fetcher[C FetcherClient[T], T any] struct {
 
 	limit      int
 	fetched    int
+	pages      int
 	first_page bool
 	next_token *string
+
+	// Set when a page could not be fetched because the context was
+	// canceled or timed out
+	interrupted bool
+
+	onPage func(pages int, rows int)
 }
 
 func NewFetcher[C FetcherClient[T], T any](
@@ -53,6 +63,13 @@ func (f Fetcher[C, T]) WithLimit(limit int) Fetcher[C, T] {
 	return f
 }
 
+// WithProgress sets a function called after each page is fetched with the
+// number of pages and rows fetched so far.
+func (f Fetcher[C, T]) WithProgress(onPage func(pages int, rows int)) Fetcher[C, T] {
+	f.onPage = onPage
+	return f
+}
+
 func (f *Fetcher[C, T]) Done() bool {
 	return !f.first_page &&
 		(f.next_token == nil || (f.limit >= 0 && f.fetched >= f.limit))
@@ -82,37 +99,40 @@ func (f *Fetcher[C, T]) NextPage() ([]T, error) {
 
 	res, err := f.client.Fetch(f.ctx)
 	if err != nil {
+		f.interrupted = f.ctx.Err() != nil
 		return nil, err
 	}
 
 	f.first_page = false
 	f.fetched += int(len(res.Data))
+	f.pages++
 	f.next_token = res.NextToken
+	if f.onPage != nil {
+		f.onPage(f.pages, f.fetched)
+	}
 	return res.Data, nil
 }
 
+// All fetches all the remaining pages. On error the results fetched so far
+// are returned with it.
 func (f *Fetcher[C, T]) All() ([]T, error) {
-	res, err := f.NextPage()
-	if err != nil {
-		return nil, err
-	}
-
-	for {
+	res := make([]T, 0)
+	for !f.Done() {
 		r, err := f.NextPage()
-		if err != nil {
-			break
-		}
-
 		res = append(res, r...)
-
-		if f.Done() {
-			break
+		if err != nil {
+			return res, err
 		}
 	}
-
 	return res, nil
 }
 
+// Interrupted reports whether a page could not be fetched because the
+// context of the fetcher was canceled or timed out.
+func (f *Fetcher[C, T]) Interrupted() bool {
+	return f.interrupted
+}
+
 func (f *Fetcher[C, T]) NextOne() (*T, error) {
 	if f.Done() {
 		return nil, fmt.Errorf("no next page")
@@ -131,7 +151,7 @@ func (f *Fetcher[C, T]) NextOne() (*T, error) {
 	f.next_token = res.NextToken
 
 	if len(res.Data) == 0 {
-		return nil, errors.New("no data")
+		return nil, ErrNoData
 	}
 	return &res.Data[0], nil
 }
@@ -0,0 +1,129 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// fakeFetcher serves pages of consecutive numbers, failing with err after
+// failAfter pages, or canceling the context if cancel is set.
+type fakeFetcher struct {
+	pages     int
+	pageSize  int
+	failAfter int
+	err       error
+	cancel    context.CancelFunc
+
+	limit  int
+	cursor *string
+}
+
+func (f *fakeFetcher) Fetch(ctx context.Context) (FetchData[int], error) {
+	page := 0
+	if f.cursor != nil {
+		page, _ = strconv.Atoi(*f.cursor)
+	}
+	if f.failAfter >= 0 && page >= f.failAfter {
+		if f.cancel != nil {
+			f.cancel()
+			return FetchData[int]{}, ctx.Err()
+		}
+		return FetchData[int]{}, f.err
+	}
+
+	fd := FetchData[int]{}
+	for i := range min(f.pageSize, f.limit) {
+		fd.Data = append(fd.Data, page*f.pageSize+i)
+	}
+	if page+1 < f.pages {
+		next := strconv.Itoa(page + 1)
+		fd.NextToken = &next
+	}
+	return fd, nil
+}
+
+func (f *fakeFetcher) RequestLimit() int {
+	return f.limit
+}
+
+func (f *fakeFetcher) SetRequestLimit(limit int) {
+	f.limit = limit
+}
+
+func (f *fakeFetcher) SetNextToken(cursor *string) {
+	f.cursor = cursor
+}
+
+func TestFetcherAll(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	tests := []struct {
+		name        string
+		failAfter   int
+		cancel      bool
+		limit       int
+		want        []int
+		err         error
+		interrupted bool
+	}{
+		{
+			name:      "all pages",
+			failAfter: -1,
+			want:      []int{0, 1, 2, 3, 4, 5},
+		},
+		{
+			name:      "limit",
+			failAfter: -1,
+			limit:     3,
+			want:      []int{0, 1, 2},
+		},
+		{
+			name:      "error keeps previous pages",
+			failAfter: 2,
+			want:      []int{0, 1, 2, 3},
+			err:       errFetch,
+		},
+		{
+			name:      "error on first page",
+			failAfter: 0,
+			want:      []int{},
+			err:       errFetch,
+		},
+		{
+			name:        "interrupted keeps previous pages",
+			failAfter:   1,
+			cancel:      true,
+			want:        []int{0, 1},
+			err:         context.Canceled,
+			interrupted: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			client := &fakeFetcher{pages: 3, pageSize: 2, failAfter: test.failAfter, err: errFetch}
+			if test.cancel {
+				client.cancel = cancel
+			}
+			fetcher := NewFetcher(ctx, client, 2)
+			if test.limit > 0 {
+				fetcher = fetcher.WithLimit(test.limit)
+			}
+
+			got, err := fetcher.All()
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("All() = %v, want %v", got, test.want)
+			}
+			if !errors.Is(err, test.err) {
+				t.Errorf("All() error = %v, want %v", err, test.err)
+			}
+			if fetcher.Interrupted() != test.interrupted {
+				t.Errorf("Interrupted() = %t, want %t", fetcher.Interrupted(), test.interrupted)
+			}
+		})
+	}
+}
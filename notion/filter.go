@@ -0,0 +1,18 @@
+package notion
+
+import (
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// EditedAfterFilter filters pages edited on or after t.
+func EditedAfterFilter(t time.Time) notionapi.Filter {
+	date := notionapi.Date(t)
+	return notionapi.TimestampFilter{
+		Timestamp: notionapi.TimestampLastEdited,
+		LastEditedTime: &notionapi.DateFilterCondition{
+			OnOrAfter: &date,
+		},
+	}
+}
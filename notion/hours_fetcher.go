@@ -52,10 +52,55 @@ func (dateFilter HoursDateExact) ToFilter() notionapi.Filter {
 	}
 }
 
+type HoursDateSince struct {
+	Date time.Time
+}
+
+func (dateFilter HoursDateSince) ToFilter() notionapi.Filter {
+	day := notionapi.Date(dateFilter.Date.Truncate(24 * time.Hour))
+
+	return notionapi.PropertyFilter{
+		Property: "data",
+		Date: &notionapi.DateFilterCondition{
+			OnOrAfter: &day,
+		},
+	}
+}
+
+// HoursDateBetween filters entries between two dates, both included.
+type HoursDateBetween struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (dateFilter HoursDateBetween) ToFilter() notionapi.Filter {
+	start := notionapi.Date(dateFilter.Start.Truncate(24 * time.Hour))
+	end := notionapi.Date(dateFilter.End.Truncate(24 * time.Hour))
+
+	return notionapi.AndCompoundFilter{
+		notionapi.PropertyFilter{
+			Property: "data",
+			Date: &notionapi.DateFilterCondition{
+				OnOrAfter: &start,
+			},
+		},
+		notionapi.PropertyFilter{
+			Property: "data",
+			Date: &notionapi.DateFilterCondition{
+				OnOrBefore: &end,
+			},
+		},
+	}
+}
+
 type HoursFilter struct {
-	Projects []string
-	Users    []string
-	Date     HoursDateFilter
+	Projects    []string
+	Commissions []string
+	Users       []string
+	Date        HoursDateFilter
+
+	// Only entries edited on or after this time
+	EditedAfter *time.Time
 }
 
 func (hoursFilter *HoursFilter) ToFilter() notionapi.Filter {
@@ -74,6 +119,19 @@ func (hoursFilter *HoursFilter) ToFilter() notionapi.Filter {
 		filter = append(filter, projectsFilter)
 	}
 
+	if len(hoursFilter.Commissions) > 0 {
+		commissionsFilter := notionapi.OrCompoundFilter{}
+		for _, commission := range hoursFilter.Commissions {
+			commissionsFilter = append(commissionsFilter, notionapi.PropertyFilter{
+				Property: "commessa",
+				Relation: &notionapi.RelationFilterCondition{
+					Contains: commission,
+				},
+			})
+		}
+		filter = append(filter, commissionsFilter)
+	}
+
 	if len(hoursFilter.Users) > 0 {
 		userFilter := notionapi.OrCompoundFilter{}
 		for _, u := range hoursFilter.Users {
@@ -94,13 +152,23 @@ func (hoursFilter *HoursFilter) ToFilter() notionapi.Filter {
 		filter = append(filter, hoursFilter.Date.ToFilter())
 	}
 
+	if hoursFilter.EditedAfter != nil {
+		filter = append(filter, EditedAfterFilter(*hoursFilter.EditedAfter))
+	}
+
+	// Notion rejects empty compound filters
+	if len(filter) == 0 {
+		return nil
+	}
 	return filter
 }
 
 type HoursEntry struct {
 	ID           string
 	Created      time.Time
+	LastEdited   time.Time
 	User         string
+	UserID       string
 	ProjectID    *string
 	TaskID       *string
 	CommissionID *string
@@ -112,7 +180,9 @@ func parseHoursEntryPage(p notionapi.Page) (HoursEntry, error) {
 	return HoursEntry{
 		ID:           p.ID.String(),
 		Created:      p.CreatedTime,
+		LastEdited:   p.LastEditedTime,
 		User:         ParsePeople(p.Properties["codeployer"])[0],
+		UserID:       ParsePeopleIDs(p.Properties["codeployer"])[0],
 		ProjectID:    OneOrNil(ParseRelation(p.Properties["progetto"])),
 		TaskID:       OneOrNil(ParseRelation(p.Properties["task"])),
 		CommissionID: OneOrNil(ParseRelation(p.Properties["commessa"])),
@@ -0,0 +1,181 @@
+package notion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// Indentation of nested blocks
+const markdownIndent = "    "
+
+// BlocksToMarkdown converts a tree of blocks to Markdown. Block types that
+// have no Markdown counterpart are skipped.
+func BlocksToMarkdown(nodes []BlockNode) string {
+	var sb strings.Builder
+	writeMarkdownBlocks(&sb, nodes, "", false)
+	return sb.String()
+}
+
+func writeMarkdownBlocks(
+	sb *strings.Builder,
+	nodes []BlockNode,
+	indent string,
+	previousIsItem bool,
+) {
+	number := 0
+	for _, node := range nodes {
+		if _, ok := node.Block.(*notionapi.NumberedListItemBlock); ok {
+			number++
+		} else {
+			number = 0
+		}
+
+		text, isItem := blockToMarkdown(node.Block, number)
+		if text == "" {
+			continue
+		}
+
+		// List items are kept together, other blocks are separated by a blank line
+		if sb.Len() > 0 {
+			if !isItem || !previousIsItem {
+				sb.WriteString("\n")
+			}
+		}
+		previousIsItem = isItem
+
+		for _, line := range strings.Split(text, "\n") {
+			if line != "" {
+				sb.WriteString(indent)
+			}
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+
+		if len(node.Children) > 0 {
+			if _, ok := node.Block.(*notionapi.TableBlock); ok {
+				writeMarkdownTable(sb, node.Children, indent)
+			} else {
+				writeMarkdownBlocks(sb, node.Children, indent+markdownIndent, isItem)
+			}
+		}
+	}
+}
+
+// blockToMarkdown converts a single block, number is the position of the
+// block in a numbered list. Also reports if the block is a list item.
+func blockToMarkdown(b notionapi.Block, number int) (string, bool) {
+	switch block := b.(type) {
+	case *notionapi.Heading1Block:
+		return "# " + RichTextToMarkdown(block.Heading1.RichText), false
+	case *notionapi.Heading2Block:
+		return "## " + RichTextToMarkdown(block.Heading2.RichText), false
+	case *notionapi.Heading3Block:
+		return "### " + RichTextToMarkdown(block.Heading3.RichText), false
+	case *notionapi.ParagraphBlock:
+		return RichTextToMarkdown(block.Paragraph.RichText), false
+	case *notionapi.QuoteBlock:
+		return "> " + RichTextToMarkdown(block.Quote.RichText), false
+	case *notionapi.CalloutBlock:
+		return "> " + RichTextToMarkdown(block.Callout.RichText), false
+	case *notionapi.BulletedListItemBlock:
+		return "- " + RichTextToMarkdown(block.BulletedListItem.RichText), true
+	case *notionapi.NumberedListItemBlock:
+		return fmt.Sprintf("%d. %s", number, RichTextToMarkdown(block.NumberedListItem.RichText)), true
+	case *notionapi.ToggleBlock:
+		return "- " + RichTextToMarkdown(block.Toggle.RichText), true
+	case *notionapi.ToDoBlock:
+		check := " "
+		if block.ToDo.Checked {
+			check = "x"
+		}
+		return fmt.Sprintf("- [%s] %s", check, RichTextToMarkdown(block.ToDo.RichText)), true
+	case *notionapi.CodeBlock:
+		return fmt.Sprintf("```%s\n%s\n```", block.Code.Language, ParseRichTextList(block.Code.RichText)), false
+	case *notionapi.EquationBlock:
+		return fmt.Sprintf("```\n%s\n```", block.Equation.Expression), false
+	case *notionapi.DividerBlock:
+		return "---", false
+	case *notionapi.ImageBlock:
+		return markdownLink(block.Image.Caption, "image", block.Image.GetURL()), false
+	case *notionapi.BookmarkBlock:
+		return markdownLink(block.Bookmark.Caption, block.Bookmark.URL, block.Bookmark.URL), false
+	case *notionapi.EmbedBlock:
+		return markdownLink(block.Embed.Caption, block.Embed.URL, block.Embed.URL), false
+	case *notionapi.LinkPreviewBlock:
+		return fmt.Sprintf("[%s](%s)", block.LinkPreview.URL, block.LinkPreview.URL), false
+	case *notionapi.ChildPageBlock:
+		return fmt.Sprintf("**%s**", block.ChildPage.Title), false
+	case *notionapi.ChildDatabaseBlock:
+		return fmt.Sprintf("**%s**", block.ChildDatabase.Title), false
+	case *notionapi.TableBlock:
+		// Rows are rendered from the children
+		return "", false
+	}
+	return "", false
+}
+
+func markdownLink(caption []notionapi.RichText, fallback string, url string) string {
+	text := ParseRichTextList(caption)
+	if text == "" {
+		text = fallback
+	}
+	return fmt.Sprintf("[%s](%s)", text, url)
+}
+
+func writeMarkdownTable(sb *strings.Builder, rows []BlockNode, indent string) {
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	for i, node := range rows {
+		row, ok := node.Block.(*notionapi.TableRowBlock)
+		if !ok {
+			continue
+		}
+		cells := make([]string, 0, len(row.TableRow.Cells))
+		for _, cell := range row.TableRow.Cells {
+			cells = append(cells, strings.ReplaceAll(RichTextToMarkdown(cell), "|", "\\|"))
+		}
+		fmt.Fprintf(sb, "%s| %s |\n", indent, strings.Join(cells, " | "))
+		if i == 0 {
+			fmt.Fprintf(sb, "%s|%s\n", indent, strings.Repeat(" --- |", len(cells)))
+		}
+	}
+}
+
+// RichTextToMarkdown converts rich text to Markdown, keeping links and
+// bold, italic, strikethrough and code annotations.
+func RichTextToMarkdown(richtext []notionapi.RichText) string {
+	var sb strings.Builder
+	for _, text := range richtext {
+		// Annotations markers must be adjacent to the text
+		value := strings.TrimSpace(text.PlainText)
+		if value == "" {
+			sb.WriteString(text.PlainText)
+			continue
+		}
+		start := strings.Index(text.PlainText, value)
+		leading, trailing := text.PlainText[:start], text.PlainText[start+len(value):]
+
+		if a := text.Annotations; a != nil {
+			if a.Code {
+				value = "`" + value + "`"
+			}
+			if a.Bold {
+				value = "**" + value + "**"
+			}
+			if a.Italic {
+				value = "_" + value + "_"
+			}
+			if a.Strikethrough {
+				value = "~~" + value + "~~"
+			}
+		}
+		if text.Href != "" {
+			value = fmt.Sprintf("[%s](%s)", value, text.Href)
+		}
+		sb.WriteString(leading + value + trailing)
+	}
+	return sb.String()
+}
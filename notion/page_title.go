@@ -0,0 +1,118 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jomei/notionapi"
+)
+
+// pageTitles remembers the titles of the pages fetched by a client.
+type pageTitles struct {
+	mu     sync.Mutex
+	titles map[string]string
+	// Pages that were deleted or not shared with the integration
+	unavailable map[string]bool
+}
+
+// isUnavailable reports whether err is the response to a request for a page
+// that was deleted or is not shared with the integration.
+func isUnavailable(err error) bool {
+	var apiErr *notionapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Status == http.StatusNotFound ||
+		apiErr.Code == "object_not_found" ||
+		apiErr.Code == "restricted_resource"
+}
+
+// PageTitles returns the titles of the pages with the given IDs. The pages
+// not already known by the client are fetched in parallel. Titles of pages
+// with a prefixed unique ID start with it, e.g. STORY-42 Fix login. Pages
+// that cannot be read, deleted or not shared with the integration, are left
+// out of the result.
+func (client *Client) PageTitles(ctx context.Context, ids []string) (map[string]string, error) {
+	client.pages.mu.Lock()
+	missing := make([]string, 0)
+	for _, id := range ids {
+		if _, ok := client.pages.titles[id]; !ok && !client.pages.unavailable[id] && !slices.Contains(missing, id) {
+			missing = append(missing, id)
+		}
+	}
+	client.pages.mu.Unlock()
+
+	group, ctx := client.NewGroup(ctx)
+	for _, id := range missing {
+		group.Go(func() error {
+			page, err := client.client.Page.Get(ctx, notionapi.PageID(id))
+			if isUnavailable(err) {
+				client.pages.mu.Lock()
+				client.pages.unavailable[id] = true
+				client.pages.mu.Unlock()
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			client.pages.mu.Lock()
+			client.pages.titles[id] = PageTitle(*page)
+			client.pages.mu.Unlock()
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	client.pages.mu.Lock()
+	defer client.pages.mu.Unlock()
+	titles := make(map[string]string, len(ids))
+	for _, id := range ids {
+		if title, ok := client.pages.titles[id]; ok {
+			titles[id] = title
+		}
+	}
+	return titles, nil
+}
+
+// PageTitle returns the title of a page, preceded by its unique ID when it
+// has a prefix.
+func PageTitle(p notionapi.Page) string {
+	title := ""
+	prefix := ""
+	for _, property := range p.Properties {
+		switch prop := property.(type) {
+		case *notionapi.TitleProperty:
+			title = ParseRichTextList(prop.Title)
+		case *notionapi.UniqueIDProperty:
+			if prop.UniqueID.Prefix != nil {
+				prefix = fmt.Sprintf("%s-%d", *prop.UniqueID.Prefix, prop.UniqueID.Number)
+			}
+		}
+	}
+	if prefix == "" {
+		return title
+	}
+	if title == "" {
+		return prefix
+	}
+	return prefix + " " + title
+}
+
+// JoinTitles joins the titles of the pages with the given IDs, IDs missing
+// from titles are left out.
+func JoinTitles(ids []string, titles map[string]string) string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if title, ok := titles[id]; ok {
+			names = append(names, title)
+		}
+	}
+	return strings.Join(names, ", ")
+}
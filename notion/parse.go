@@ -2,6 +2,8 @@ package notion
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jomei/notionapi"
@@ -22,7 +24,10 @@ func ParseTitle(p notionapi.Property) string {
 }
 
 func ParseRichText(p notionapi.Property) string {
-	richtext := p.(*notionapi.RichTextProperty).RichText
+	return ParseRichTextList(p.(*notionapi.RichTextProperty).RichText)
+}
+
+func ParseRichTextList(richtext []notionapi.RichText) string {
 	result := ""
 	for _, text := range richtext {
 		result += text.PlainText
@@ -47,6 +52,16 @@ func ParsePeople(p notionapi.Property) []string {
 	return result
 }
 
+// ParsePeopleIDs returns the IDs of the users of a people property.
+func ParsePeopleIDs(p notionapi.Property) []string {
+	users := p.(*notionapi.PeopleProperty).People
+	result := make([]string, 0, len(users))
+	for _, user := range users {
+		result = append(result, user.ID.String())
+	}
+	return result
+}
+
 func ParseUserName(p notionapi.Property, fallback string) string {
 	users := p.(*notionapi.PeopleProperty).People
 	if len(users) == 0 {
@@ -73,20 +88,17 @@ type DateRange struct {
 	End   time.Time
 }
 
+// ParseDate returns the dates of a date property, zero if it is empty.
 func ParseDate(p notionapi.Property) DateRange {
-	startDate, err := time.Parse(
-		time.RFC3339,
-		p.(*notionapi.DateProperty).Date.Start.String(),
-	)
-	if err != nil {
-		startDate = time.Now()
+	date := p.(*notionapi.DateProperty).Date
+	if date == nil || date.Start == nil {
+		return DateRange{}
 	}
-	endDate, err := time.Parse(
-		time.RFC3339,
-		p.(*notionapi.DateProperty).Date.Start.String(),
-	)
-	if err != nil {
-		startDate = time.Now()
+
+	startDate := time.Time(*date.Start)
+	endDate := startDate
+	if date.End != nil {
+		endDate = time.Time(*date.End)
 	}
 
 	return DateRange{
@@ -106,3 +118,91 @@ func OneOrNil[T any](value []T) *T {
 	}
 	return nil
 }
+
+// ParsePropertyValue formats any property as a string, dates are formatted
+// using the given layout.
+func ParsePropertyValue(p notionapi.Property, layout string) string {
+	switch prop := p.(type) {
+	case *notionapi.TitleProperty:
+		return ParseRichTextList(prop.Title)
+	case *notionapi.RichTextProperty:
+		return ParseRichTextList(prop.RichText)
+	case *notionapi.NumberProperty:
+		return strconv.FormatFloat(prop.Number, 'f', -1, 64)
+	case *notionapi.SelectProperty:
+		return prop.Select.Name
+	case *notionapi.MultiSelectProperty:
+		names := make([]string, 0, len(prop.MultiSelect))
+		for _, option := range prop.MultiSelect {
+			names = append(names, option.Name)
+		}
+		return strings.Join(names, ", ")
+	case *notionapi.StatusProperty:
+		return prop.Status.Name
+	case *notionapi.DateProperty:
+		return formatDateObject(prop.Date, layout)
+	case *notionapi.PeopleProperty:
+		return strings.Join(ParsePeople(prop), ", ")
+	case *notionapi.RelationProperty:
+		return strings.Join(ParseRelation(prop), ", ")
+	case *notionapi.CheckboxProperty:
+		return strconv.FormatBool(prop.Checkbox)
+	case *notionapi.URLProperty:
+		return prop.URL
+	case *notionapi.EmailProperty:
+		return prop.Email
+	case *notionapi.PhoneNumberProperty:
+		return prop.PhoneNumber
+	case *notionapi.CreatedTimeProperty:
+		return prop.CreatedTime.Local().Format(layout)
+	case *notionapi.CreatedByProperty:
+		return prop.CreatedBy.Name
+	case *notionapi.LastEditedTimeProperty:
+		return prop.LastEditedTime.Local().Format(layout)
+	case *notionapi.LastEditedByProperty:
+		return prop.LastEditedBy.Name
+	case *notionapi.UniqueIDProperty:
+		if prop.UniqueID.Prefix != nil {
+			return fmt.Sprintf("%s-%d", *prop.UniqueID.Prefix, prop.UniqueID.Number)
+		}
+		return strconv.Itoa(prop.UniqueID.Number)
+	case *notionapi.FilesProperty:
+		names := make([]string, 0, len(prop.Files))
+		for _, file := range prop.Files {
+			names = append(names, file.Name)
+		}
+		return strings.Join(names, ", ")
+	case *notionapi.FormulaProperty:
+		switch prop.Formula.Type {
+		case "string":
+			return prop.Formula.String
+		case "number":
+			return strconv.FormatFloat(prop.Formula.Number, 'f', -1, 64)
+		case "boolean":
+			return strconv.FormatBool(prop.Formula.Boolean)
+		case "date":
+			return formatDateObject(prop.Formula.Date, layout)
+		}
+	case *notionapi.RollupProperty:
+		switch prop.Rollup.Type {
+		case "number":
+			return strconv.FormatFloat(prop.Rollup.Number, 'f', -1, 64)
+		case "date":
+			return formatDateObject(prop.Rollup.Date, layout)
+		case "array":
+			return fmt.Sprintf("%d items", len(prop.Rollup.Array))
+		}
+	}
+	return ""
+}
+
+func formatDateObject(date *notionapi.DateObject, layout string) string {
+	if date == nil || date.Start == nil {
+		return ""
+	}
+	result := time.Time(*date.Start).Local().Format(layout)
+	if date.End != nil {
+		result += " - " + time.Time(*date.End).Local().Format(layout)
+	}
+	return result
+}
@@ -59,6 +59,7 @@ type Sprint struct {
 	SprintID int
 	Name     string
 	Status   string
+	Dates    DateRange
 }
 
 type SprintFetcher struct {
@@ -91,12 +92,16 @@ func (fetcher *SprintFetcher) Fetch(
 
 	sprints := make([]Sprint, 0)
 	for _, result := range res.Results {
-		sprints = append(sprints, Sprint{
+		sprint := Sprint{
 			ID:       result.ID.String(),
 			SprintID: ParseUniqueID(result.Properties["Sprint ID"]),
 			Name:     ParseTitle(result.Properties["Sprint name"]),
 			Status:   ParseStatus(result.Properties["Sprint status"]),
-		})
+		}
+		if dates, ok := result.Properties["Dates"]; ok {
+			sprint.Dates = ParseDate(dates)
+		}
+		sprints = append(sprints, sprint)
 	}
 
 	fd := FetchData[Sprint]{
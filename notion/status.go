@@ -0,0 +1,51 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jomei/notionapi"
+)
+
+// StatusOption is an option of a status property of a database.
+type StatusOption struct {
+	Name  string
+	Color string
+	// Group is the name of the group of the option, e.g. Complete
+	Group string
+}
+
+// FetchStatusOptions returns the options of the status property of a
+// database, in the order defined in notion.
+func (client *Client) FetchStatusOptions(
+	ctx context.Context,
+	databaseID string,
+	property string,
+) ([]StatusOption, error) {
+	db, err := client.client.Database.Get(ctx, notionapi.DatabaseID(databaseID))
+	if err != nil {
+		return nil, err
+	}
+
+	config, ok := db.Properties[property].(*notionapi.StatusPropertyConfig)
+	if !ok {
+		return nil, fmt.Errorf("property '%s' is not a status", property)
+	}
+
+	groups := make(map[string]string)
+	for _, group := range config.Status.Groups {
+		for _, id := range group.OptionIDs {
+			groups[id.String()] = group.Name
+		}
+	}
+
+	options := make([]StatusOption, 0, len(config.Status.Options))
+	for _, option := range config.Status.Options {
+		options = append(options, StatusOption{
+			Name:  option.Name,
+			Color: string(option.Color),
+			Group: groups[string(option.ID)],
+		})
+	}
+	return options, nil
+}
@@ -2,11 +2,13 @@ package notion
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/jomei/notionapi"
 )
 
+// Default statuses of tasks, see config.Statuses for the configured ones
 const (
 	StatusNotStarted = "Not Started"
 	StatusInProgress = "In Progress"
@@ -73,6 +75,8 @@ func (sprintFilter TaskSprintByIDs) ToFilter() notionapi.Filter {
 }
 
 type TaskFilter struct {
+	StoryID   *int
+	Names     []string
 	Projects  []string
 	Users     []string
 	Assignees []string
@@ -80,11 +84,32 @@ type TaskFilter struct {
 	Statuses  []string
 	Sprint    TaskSprintFilter
 	Estimate  string
+
+	// Only tasks edited on or after this time
+	EditedAfter *time.Time
 }
 
 func (taskFilter *TaskFilter) ToFilter() notionapi.Filter {
 	filter := notionapi.AndCompoundFilter{}
 
+	if taskFilter.StoryID != nil {
+		filter = append(filter, notionapi.PropertyFilter{
+			Property: "Story ID",
+			UniqueId: &notionapi.UniqueIdFilterCondition{
+				Equals: taskFilter.StoryID,
+			},
+		})
+	}
+
+	for _, name := range taskFilter.Names {
+		filter = append(filter, notionapi.PropertyFilter{
+			Property: "Task name",
+			RichText: &notionapi.TextFilterCondition{
+				Contains: name,
+			},
+		})
+	}
+
 	if len(taskFilter.Projects) > 0 {
 		projectsFilter := notionapi.OrCompoundFilter{}
 		for _, project := range taskFilter.Projects {
@@ -169,38 +194,64 @@ func (taskFilter *TaskFilter) ToFilter() notionapi.Filter {
 		filter = append(filter, (taskFilter.Sprint).ToFilter())
 	}
 
+	if taskFilter.EditedAfter != nil {
+		filter = append(filter, EditedAfterFilter(*taskFilter.EditedAfter))
+	}
+
 	return filter
 }
 
 type Task struct {
-	ID        string
-	StoryID   int
-	Name      string
-	Assignee  string
-	Reviewer  string
-	Status    string
-	Priority  string
-	ProjectID *string
-	Created   time.Time
-	Estimate  float64
-	SprintID  string
-	URL       string
+	ID          string
+	StoryID     int
+	Name        string
+	Assignee    string
+	AssigneeIDs []string
+	Reviewer    string
+	ReviewerIDs []string
+	Status      string
+	Priority    string
+	ProjectID   *string
+	Created     time.Time
+	LastEdited  time.Time
+	EditorID    string
+	Estimate    float64
+	SprintIDs   []string
+	URL         string
+	Properties  notionapi.Properties
+}
+
+// IsAssignee reports whether the user with the given ID is one of the
+// assignees of the task.
+func (task Task) IsAssignee(userID string) bool {
+	return slices.Contains(task.AssigneeIDs, userID)
+}
+
+// IsReviewer reports whether the user with the given ID is one of the
+// reviewers of the task.
+func (task Task) IsReviewer(userID string) bool {
+	return slices.Contains(task.ReviewerIDs, userID)
 }
 
 func parseTaskPage(p notionapi.Page) (Task, error) {
 	return Task{
-		ID:        p.ID.String(),
-		StoryID:   ParseUniqueID(p.Properties["Story ID"]),
-		Name:      ParseTitle(p.Properties["Task name"]),
-		Status:    ParseStatus(p.Properties["Status"]),
-		Assignee:  ParseUserName(p.Properties["Assignee"], "-"),
-		Reviewer:  ParseUserName(p.Properties["Reviewer"], "-"),
-		Priority:  ParseSelect(p.Properties["Priority"]),
-		ProjectID: OneOrNil(ParseRelation(p.Properties["Project"])),
-		Created:   p.CreatedTime,
-		Estimate:  ParseNumber(p.Properties["estimate hours"]),
-		SprintID:  ParseRelation(p.Properties["Sprint"])[0],
-		URL:       p.URL,
+		ID:          p.ID.String(),
+		StoryID:     ParseUniqueID(p.Properties["Story ID"]),
+		Name:        ParseTitle(p.Properties["Task name"]),
+		Status:      ParseStatus(p.Properties["Status"]),
+		Assignee:    ParseUserName(p.Properties["Assignee"], "-"),
+		AssigneeIDs: ParsePeopleIDs(p.Properties["Assignee"]),
+		Reviewer:    ParseUserName(p.Properties["Reviewer"], "-"),
+		ReviewerIDs: ParsePeopleIDs(p.Properties["Reviewer"]),
+		Priority:    ParseSelect(p.Properties["Priority"]),
+		ProjectID:   OneOrNil(ParseRelation(p.Properties["Project"])),
+		Created:     p.CreatedTime,
+		LastEdited:  p.LastEditedTime,
+		EditorID:    p.LastEditedBy.ID.String(),
+		Estimate:    ParseNumber(p.Properties["estimate hours"]),
+		SprintIDs:   ParseRelation(p.Properties["Sprint"]),
+		URL:         p.URL,
+		Properties:  p.Properties,
 	}, nil
 }
 
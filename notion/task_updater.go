@@ -0,0 +1,35 @@
+package notion
+
+import (
+	"context"
+
+	"github.com/jomei/notionapi"
+)
+
+// SetTaskSprints replaces the sprints a task belongs to.
+func (client *Client) SetTaskSprints(
+	ctx context.Context,
+	taskID string,
+	sprintIDs []string,
+) error {
+	relation := make([]notionapi.Relation, 0, len(sprintIDs))
+	for _, id := range sprintIDs {
+		relation = append(relation, notionapi.Relation{
+			ID: notionapi.PageID(id),
+		})
+	}
+
+	_, err := client.client.Page.Update(
+		ctx,
+		notionapi.PageID(taskID),
+		&notionapi.PageUpdateRequest{
+			Properties: notionapi.Properties{
+				"Sprint": notionapi.RelationProperty{
+					Type:     notionapi.PropertyTypeRelation,
+					Relation: relation,
+				},
+			},
+		},
+	)
+	return err
+}
@@ -43,3 +43,8 @@ func PrintlnfWarn(format string, a ...any) {
 func PrintlnfSuccess(format string, a ...any) {
 	fmt.Fprint(os.Stderr, SuccessStyle.Render(fmt.Sprintf(format, a...))+"\n")
 }
+
+// ClearScreen clears the terminal and moves the cursor to the top left.
+func ClearScreen() {
+	fmt.Fprint(os.Stdout, "\033[H\033[2J")
+}
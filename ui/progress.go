@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Interval between redraws of the elapsed time
+const progressInterval = 100 * time.Millisecond
+
+// FetchProgress shows on stderr the pages and rows fetched so far and the
+// elapsed time, on a single line updated in place.
+type FetchProgress struct {
+	title   string
+	enabled bool
+	start   time.Time
+
+	mu    sync.Mutex
+	pages int
+	rows  int
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewFetchProgress starts showing the progress of a fetch. It shows nothing
+// when not enabled or when stderr is not a terminal.
+func NewFetchProgress(title string, enabled bool) *FetchProgress {
+	p := &FetchProgress{
+		title:   title,
+		enabled: enabled && IsTerminal(os.Stderr),
+		start:   time.Now(),
+		done:    make(chan struct{}),
+	}
+	if !p.enabled {
+		return p
+	}
+
+	p.draw()
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				p.draw()
+			}
+		}
+	}()
+	return p
+}
+
+// Update sets the pages and rows fetched so far.
+func (p *FetchProgress) Update(pages int, rows int) {
+	if !p.enabled {
+		return
+	}
+	p.mu.Lock()
+	p.pages = pages
+	p.rows = rows
+	p.mu.Unlock()
+	p.draw()
+}
+
+// Stop stops showing the progress and clears its line.
+func (p *FetchProgress) Stop() {
+	if !p.enabled {
+		return
+	}
+	close(p.done)
+	p.wg.Wait()
+	p.enabled = false
+	fmt.Fprint(os.Stderr, "\r\033[K")
+}
+
+func (p *FetchProgress) draw() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprint(os.Stderr, "\r\033[K"+InfoStyle.Render(fmt.Sprintf(
+		"%s: %d pages, %d rows, %s",
+		p.title,
+		p.pages,
+		p.rows,
+		time.Since(p.start).Truncate(progressInterval),
+	)))
+}
+
+// IsTerminal reports whether f is a terminal.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,21 @@
+package ui
+
+var sparklineBars = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a line of bars scaled to the maximum value.
+func Sparkline(values []float64) string {
+	maxValue := 0.0
+	for _, v := range values {
+		maxValue = max(maxValue, v)
+	}
+
+	line := make([]rune, 0, len(values))
+	for _, v := range values {
+		i := 0
+		if maxValue > 0 {
+			i = int(v / maxValue * float64(len(sparklineBars)-1))
+		}
+		line = append(line, sparklineBars[max(0, i)])
+	}
+	return string(line)
+}
@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -25,3 +28,59 @@ const (
 )
 
 var TitleStyle = lipgloss.NewStyle().Foreground(Primary).Bold(true)
+
+var HighlightStyle = lipgloss.NewStyle().Foreground(Accent).Bold(true)
+
+// Terminal colors of notion color names
+var notionColors = map[string]lipgloss.Color{
+	"gray":   lipgloss.Color("8"),
+	"brown":  lipgloss.Color("94"),
+	"orange": lipgloss.Color("208"),
+	"yellow": lipgloss.Color("3"),
+	"green":  lipgloss.Color("2"),
+	"blue":   lipgloss.Color("4"),
+	"purple": lipgloss.Color("5"),
+	"pink":   lipgloss.Color("13"),
+	"red":    lipgloss.Color("1"),
+}
+
+// ColorOf returns the terminal color of a notion color name, ANSI color
+// numbers and hex colors are used as they are. Reports false for empty and
+// default colors.
+func ColorOf(name string) (lipgloss.Color, bool) {
+	if name == "" || name == "default" {
+		return "", false
+	}
+	if color, ok := notionColors[name]; ok {
+		return color, true
+	}
+	return lipgloss.Color(name), true
+}
+
+// Hex values of the 16 basic ANSI colors, as shown by xterm
+var ansiHex = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// Levels of the red, green and blue components of the 256 colors cube
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// HexOf returns the hex value of a terminal color, to use the same colors
+// outside the terminal. ANSI color numbers follow the xterm palette, other
+// colors are returned as they are.
+func HexOf(color lipgloss.Color) string {
+	n, err := strconv.Atoi(string(color))
+	if err != nil || n < 0 || n > 255 {
+		return string(color)
+	}
+	switch {
+	case n < 16:
+		return ansiHex[n]
+	case n < 232:
+		n -= 16
+		return fmt.Sprintf("#%02x%02x%02x", cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6])
+	}
+	gray := 8 + (n-232)*10
+	return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+}